@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ACLAuthorizer gives owners and admins full access to files and other callers
+// the permission granted to their subject or to EveryoneGrant. Only owners and admins may share files.
+// Files without owner, uploaded before access control, are not restricted.
+type ACLAuthorizer struct {
+	admins map[string]struct{}
+}
+
+// NewACLAuthorizer creates an authorizer giving the admin subjects full access to all files.
+func NewACLAuthorizer(admins ...string) *ACLAuthorizer {
+	aa := &ACLAuthorizer{
+		admins: make(map[string]struct{}, len(admins)),
+	}
+	for _, admin := range admins {
+		aa.admins[admin] = struct{}{}
+	}
+	return aa
+}
+
+func (aa *ACLAuthorizer) Authorize(ctx context.Context, action Action, info *FileInfo) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	if action == ActionCreate || info.Owner == "" || info.Owner == identity.Subject {
+		return nil
+	}
+
+	if _, ok := aa.admins[identity.Subject]; ok {
+		return nil
+	}
+
+	if action == ActionShare {
+		return status.Errorf(codes.PermissionDenied, "only the owner can share file %s", info.ID)
+	}
+
+	required := PermissionWrite
+	if action == ActionRead {
+		required = PermissionRead
+	}
+
+	if info.Grants[identity.Subject] >= required || info.Grants[EveryoneGrant] >= required {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s of file %s is not allowed", action, info.ID)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestACLAuthorizer_Authorize(t *testing.T) {
+	owned := &FileInfo{
+		ID:    "1",
+		Owner: "alice",
+		Grants: map[string]Permission{
+			"reader": PermissionRead,
+			"writer": PermissionWrite,
+		},
+	}
+	public := &FileInfo{
+		ID:     "2",
+		Owner:  "alice",
+		Grants: map[string]Permission{EveryoneGrant: PermissionRead},
+	}
+	ownerless := &FileInfo{ID: "3"}
+
+	tests := []struct {
+		name    string
+		subject string
+		action  Action
+		info    *FileInfo
+		code    codes.Code
+	}{
+		{name: "anonymous", action: ActionRead, info: ownerless, code: codes.Unauthenticated},
+		{name: "anonymous create", action: ActionCreate, info: &FileInfo{}, code: codes.Unauthenticated},
+		{name: "create", subject: "bob", action: ActionCreate, info: &FileInfo{Owner: "bob"}},
+
+		{name: "owner read", subject: "alice", action: ActionRead, info: owned},
+		{name: "owner delete", subject: "alice", action: ActionDelete, info: owned},
+		{name: "owner share", subject: "alice", action: ActionShare, info: owned},
+
+		{name: "admin update", subject: "root", action: ActionUpdate, info: owned},
+		{name: "admin share", subject: "root", action: ActionShare, info: owned},
+
+		{name: "reader read", subject: "reader", action: ActionRead, info: owned},
+		{name: "reader update", subject: "reader", action: ActionUpdate, info: owned, code: codes.PermissionDenied},
+		{name: "reader delete", subject: "reader", action: ActionDelete, info: owned, code: codes.PermissionDenied},
+
+		{name: "writer read", subject: "writer", action: ActionRead, info: owned},
+		{name: "writer update", subject: "writer", action: ActionUpdate, info: owned},
+		{name: "writer delete", subject: "writer", action: ActionDelete, info: owned},
+		{name: "writer share", subject: "writer", action: ActionShare, info: owned, code: codes.PermissionDenied},
+
+		{name: "stranger read", subject: "bob", action: ActionRead, info: owned, code: codes.PermissionDenied},
+		{name: "stranger update", subject: "bob", action: ActionUpdate, info: owned, code: codes.PermissionDenied},
+
+		{name: "everyone read", subject: "bob", action: ActionRead, info: public},
+		{name: "everyone update", subject: "bob", action: ActionUpdate, info: public, code: codes.PermissionDenied},
+		{name: "everyone share", subject: "bob", action: ActionShare, info: public, code: codes.PermissionDenied},
+
+		{name: "ownerless update", subject: "bob", action: ActionUpdate, info: ownerless},
+	}
+
+	authorizer := NewACLAuthorizer("root")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.subject != "" {
+				ctx = ContextWithIdentity(ctx, &Identity{Subject: tt.subject})
+			}
+
+			err := authorizer.Authorize(ctx, tt.action, tt.info)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got error %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestFileServiceApi_VersionGrants(t *testing.T) {
+	storage := newMemStorage()
+	fsa := NewFileServiceStreamApi(storage, storage, testSettings{})
+	fsa.SetAuthorizer(NewACLAuthorizer("root"))
+
+	ctx := context.Background()
+	meta := &UploadMeta{
+		Filename: "report.txt",
+		Owner:    "alice",
+		Grants:   map[string]Permission{"reader": PermissionRead},
+	}
+	id, err := storage.UploadStream(ctx, meta, strings.NewReader("v1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := storage.UploadVersion(ctx, id, meta, strings.NewReader("v2")); err != nil {
+		t.Fatal(err)
+	}
+
+	alice := ContextWithIdentity(ctx, &Identity{Subject: "alice"})
+	reader := ContextWithIdentity(ctx, &Identity{Subject: "reader"})
+
+	read := func() error {
+		if _, err := fsa.GetFileInfo(reader, &file_svc_v1.FileReq{Id: id, Version: 1}); err != nil {
+			return err
+		}
+		file, _, err := fsa.Open(reader, &file_svc_v1.DownloadReq{Id: id, Version: 1})
+		if err != nil {
+			return err
+		}
+		return file.Close()
+	}
+
+	if err := read(); err != nil {
+		t.Fatalf("granted read of version 1: %v", err)
+	}
+
+	_, err = fsa.UpdateFileInfo(alice, &file_svc_v1.UpdateFileInfoReq{
+		Id:         id,
+		Info:       &file_svc_v1.FileInfoResp{},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"grants"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// version 1 keeps the revoked grant, the current info decides
+	if err := read(); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("read of version 1 after revoke got %v, want %s", err, codes.PermissionDenied)
+	}
+}
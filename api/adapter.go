@@ -0,0 +1,48 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+type streamAdapter struct {
+	svc FileService
+}
+
+// NewStreamAdapter exposes a byte slice FileService as a StreamFileService.
+// Contents are still buffered in memory by the adapter.
+func NewStreamAdapter(svc FileService) StreamFileService {
+	return &streamAdapter{svc: svc}
+}
+
+func (sa *streamAdapter) UploadStream(_ context.Context, meta *UploadMeta, file io.Reader) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	return sa.svc.Upload(meta.Filename, data)
+}
+
+func (sa *streamAdapter) DownloadStream(_ context.Context, id string) (io.ReadCloser, error) {
+	data, err := sa.svc.Download(id)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (sa *streamAdapter) DeleteFile(id string) error {
+	return sa.svc.DeleteFile(id)
+}
+
+// extension returns the backend as T, looking through the stream adapter
+// to the byte slice backend when needed.
+func extension[T any](svc StreamFileService) (T, bool) {
+	if adapter, ok := svc.(*streamAdapter); ok {
+		ext, ok := adapter.svc.(T)
+		return ext, ok
+	}
+	ext, ok := svc.(T)
+	return ext, ok
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
+	"io"
 	"log/slog"
+	"time"
 
 	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
 	"github.com/vishenosik/gocherry/pkg/logs"
@@ -10,6 +13,8 @@ import (
 
 const (
 	FilenameHeader = "filename"
+	// ChecksumTrailer holds the hex encoded SHA-256 of the downloaded bytes.
+	ChecksumTrailer = "checksum-sha256"
 )
 
 type FileService interface {
@@ -18,9 +23,102 @@ type FileService interface {
 	DeleteFile(id string) error
 }
 
+// StreamFileService is a storage backend that receives and serves file
+// contents as streams instead of holding whole files in memory.
+type StreamFileService interface {
+	UploadStream(ctx context.Context, meta *UploadMeta, file io.Reader) (id string, err error)
+	DownloadStream(ctx context.Context, id string) (file io.ReadCloser, err error)
+	DeleteFile(id string) error
+}
+
+// RangeFileService is an optional StreamFileService extension
+// which reads length bytes of a file starting at offset.
+type RangeFileService interface {
+	DownloadRange(ctx context.Context, id string, offset, length uint32) (file io.ReadCloser, err error)
+}
+
+// UploadSessionService is an optional StreamFileService extension for resumable uploads.
+// Bytes appended to a session are kept until the session is committed into a file.
+type UploadSessionService interface {
+	CreateUploadSession(ctx context.Context, meta *UploadMeta) (sessionID string, err error)
+	GetUploadSession(ctx context.Context, sessionID string) (session *UploadSession, err error)
+	// AppendUploadSession stores chunk at offset, which must match the committed size.
+	// Bytes read before a chunk failure stay committed.
+	AppendUploadSession(ctx context.Context, sessionID string, offset uint32, chunk io.Reader) error
+	ReadUploadSession(ctx context.Context, sessionID string) (file io.ReadCloser, err error)
+	// CommitUploadSession turns the session into a file described by meta.
+	CommitUploadSession(ctx context.Context, sessionID string, meta *UploadMeta) (id string, err error)
+}
+
+// BatchDeleter is an optional StreamFileService extension which deletes
+// several files at once. errs holds the result of each id, nil when deleted,
+// err fails the whole batch.
+type BatchDeleter interface {
+	DeleteFiles(ctx context.Context, ids []string) (errs []error, err error)
+}
+
+// Copier is an optional StreamFileService extension which copies
+// or links file contents inside the backend.
+type Copier interface {
+	// CopyFile creates a file described by meta with the contents of sourceID.
+	CopyFile(ctx context.Context, sourceID string, meta *UploadMeta) (id string, err error)
+}
+
+// Versioned is an optional StreamFileService extension which keeps
+// the previous contents of files. Version zero is never stored,
+// versions of a file are numbered from one.
+type Versioned interface {
+	// UploadVersion stores file as a new version of id. Empty id selects the file
+	// by meta.Bucket and meta.Filename, creating it when it does not exist.
+	UploadVersion(ctx context.Context, id string, meta *UploadMeta, file io.Reader) (fileID string, version uint32, err error)
+	GetVersionInfo(ctx context.Context, id string, version uint32) (info *FileInfo, err error)
+	DownloadVersion(ctx context.Context, id string, version uint32) (file io.ReadCloser, err error)
+	// DeleteVersion deletes a single version, the file is kept while other versions exist.
+	DeleteVersion(ctx context.Context, id string, version uint32) error
+	ListVersions(ctx context.Context, id string) (versions []*FileInfo, err error)
+}
+
+type UploadSession struct {
+	ID        string
+	Meta      *UploadMeta
+	Committed uint32
+}
+
+// UploadMeta describes a file passed to StreamFileService.UploadStream.
+type UploadMeta struct {
+	Filename string
+	// Size is the size declared by the client, zero if unknown.
+	Size        uint32
+	ContentType string
+	// Checksum is the SHA-256 of the contents. It is set once the file
+	// has been read to EOF, unless the client declared it upfront.
+	Checksum []byte
+	Labels   map[string]string
+	Bucket   string
+	// ExpiresAt is when the file is deleted, zero if it does not expire.
+	ExpiresAt time.Time
+	// Owner is the subject of the uploading caller, empty without authentication.
+	Owner  string
+	Grants map[string]Permission
+}
+
 type Info interface {
 	GetFileInfo(id string) (info *FileInfo, err error)
-	ListFiles() (list *FileInfoList, err error)
+	ListFiles(query *ListQuery) (list *FileInfoList, err error)
+}
+
+// LabelInfo is an optional Info extension for backends which persist labels.
+// Backends implementing it store UploadMeta.Labels, return them in FileInfo
+// and filter ListFiles by ListQuery.LabelSelector.
+type LabelInfo interface {
+	// UpdateFileLabels sets labels on the file and removes the remove keys.
+	UpdateFileLabels(ctx context.Context, id string, labels map[string]string, remove []string) (info *FileInfo, err error)
+}
+
+// Updater is an optional Info extension which changes file info without
+// uploading the file again.
+type Updater interface {
+	UpdateFileInfo(ctx context.Context, id string, update *FileInfoUpdate) (info *FileInfo, err error)
 }
 
 type Settings interface {
@@ -30,14 +128,25 @@ type Settings interface {
 
 type FileServiceApi struct {
 	file_svc_v1.UnimplementedFileServiceServer
-	svc      FileService
+	svc      StreamFileService
 	settings Settings
 	info     Info
+	// authorizer is nil when access control is disabled.
+	authorizer Authorizer
 	// log is a structured logger for the application.
 	log *slog.Logger
 }
 
+// NewFileServiceApi creates the gRPC API on top of a byte slice backend.
+// Backends which also implement StreamFileService are used as streams directly.
 func NewFileServiceApi(svc FileService, info Info, s Settings) *FileServiceApi {
+	if stream, ok := svc.(StreamFileService); ok {
+		return NewFileServiceStreamApi(stream, info, s)
+	}
+	return NewFileServiceStreamApi(NewStreamAdapter(svc), info, s)
+}
+
+func NewFileServiceStreamApi(svc StreamFileService, info Info, s Settings) *FileServiceApi {
 	return &FileServiceApi{
 		svc:      svc,
 		settings: s,
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys carrying credentials.
+const (
+	AuthorizationHeader = "authorization"
+	APIKeyHeader        = "x-api-key"
+
+	bearerScheme = "bearer"
+)
+
+// ErrNoCredentials is returned by authenticators when the request has no credentials
+// of their scheme, so the next authenticator of a chain is tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// errCredentialsRequired is the status of requests without credentials.
+var errCredentialsRequired = status.Error(codes.Unauthenticated, "credentials are required")
+
+// Identity is the authenticated caller.
+type Identity struct {
+	Subject string
+	// Claims are the scheme attributes of the caller, e.g. JWT claims.
+	Claims map[string]any
+}
+
+// Authenticator authenticates requests by their metadata. Invalid credentials
+// should fail with a status error, usually codes.Unauthenticated.
+type Authenticator interface {
+	Authenticate(ctx context.Context, md metadata.MD) (*Identity, error)
+}
+
+// AuthenticatorFunc adapts a function to Authenticator.
+type AuthenticatorFunc func(ctx context.Context, md metadata.MD) (*Identity, error)
+
+func (fn AuthenticatorFunc) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	return fn(ctx, md)
+}
+
+// ChainAuthenticators tries authenticators in order until one finds its credentials.
+func ChainAuthenticators(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, md metadata.MD) (*Identity, error) {
+		for _, auth := range authenticators {
+			identity, err := auth.Authenticate(ctx, md)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			}
+			return identity, err
+		}
+		return nil, ErrNoCredentials
+	})
+}
+
+type identityKey struct{}
+
+// ContextWithIdentity returns a copy of ctx carrying identity.
+func ContextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller identity set by the auth interceptors.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// AuthServerOptions returns the server options authenticating every call with auth.
+func AuthServerOptions(auth Authenticator) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(auth)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(auth)),
+	}
+}
+
+// UnaryAuthInterceptor rejects unauthenticated calls and puts the caller identity in the context.
+func UnaryAuthInterceptor(auth Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx, err := Authenticate(ctx, auth, md)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor rejects unauthenticated streams and puts the caller identity in the context.
+// Downloads and uploads without credentials are let through when they start with a signed token.
+func StreamAuthInterceptor(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		ctx, err := Authenticate(stream.Context(), auth, md)
+		if err != nil {
+			// only requests without credentials may be authorized by a signed token
+			if errors.Is(err, errCredentialsRequired) && signedMethods[info.FullMethod] {
+				return handler(srv, &signedStream{ServerStream: stream, err: err})
+			}
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// Authenticate authenticates md with auth and returns ctx with the caller identity.
+// Failures are Unauthenticated status errors unless auth returned another status.
+func Authenticate(ctx context.Context, auth Authenticator, md metadata.MD) (context.Context, error) {
+	identity, err := auth.Authenticate(ctx, md)
+	if errors.Is(err, ErrNoCredentials) {
+		return nil, errCredentialsRequired
+	}
+	if err != nil {
+		return nil, statusAuthError(err)
+	}
+	if identity == nil {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	return ContextWithIdentity(ctx, identity), nil
+}
+
+// statusAuthError reports authenticator errors without a status as Unauthenticated.
+func statusAuthError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Unauthenticated, "cannot authenticate: %v", err)
+}
+
+// bearerToken returns the token of the authorization bearer header.
+func bearerToken(md metadata.MD) (string, bool) {
+	for _, value := range md.Get(AuthorizationHeader) {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, bearerScheme) {
+			return strings.TrimSpace(token), true
+		}
+	}
+	return "", false
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (is *identityStream) Context() context.Context {
+	return is.ctx
+}
+
+// signedMethods accept signed tokens instead of credentials.
+var signedMethods = map[string]bool{
+	file_svc_v1.FileService_DownloadStream_FullMethodName: true,
+	file_svc_v1.FileService_UploadStream_FullMethodName:   true,
+}
+
+// signedStream fails unauthenticated streams which do not start with a signed token.
+type signedStream struct {
+	grpc.ServerStream
+	// err is the authentication error, cleared once the first message carries a token.
+	err     error
+	checked bool
+}
+
+func (ss *signedStream) RecvMsg(m any) error {
+	if ss.checked {
+		if ss.err != nil {
+			return ss.err
+		}
+		return ss.ServerStream.RecvMsg(m)
+	}
+	ss.checked = true
+
+	// streams closed or failed before the first message are not signed
+	if err := ss.ServerStream.RecvMsg(m); err != nil {
+		return ss.err
+	}
+
+	var token string
+	switch req := m.(type) {
+	case *file_svc_v1.DownloadReq:
+		token = req.GetToken()
+	case *file_svc_v1.UploadStreamMsg:
+		// legacy uploads starting with a chunk cannot carry a token
+		token = req.GetHeader().GetToken()
+	}
+
+	if token == "" {
+		return ss.err
+	}
+
+	ss.err = nil
+	return nil
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testStream replays msgs to RecvMsg, then io.EOF.
+type testStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []proto.Message
+}
+
+func (ts *testStream) Context() context.Context {
+	return ts.ctx
+}
+
+func (ts *testStream) RecvMsg(m any) error {
+	if len(ts.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), ts.msgs[0])
+	ts.msgs = ts.msgs[1:]
+	return nil
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	auth := NewAPIKeyAuthenticator(map[string]string{"key": "alice"})
+
+	download := file_svc_v1.FileService_DownloadStream_FullMethodName
+	upload := file_svc_v1.FileService_UploadStream_FullMethodName
+
+	tests := []struct {
+		name    string
+		method  string
+		md      metadata.MD
+		msgs    []proto.Message
+		subject string
+		code    codes.Code
+	}{
+		{
+			name:    "api key",
+			method:  upload,
+			md:      metadata.Pairs(APIKeyHeader, "key"),
+			subject: "alice",
+		},
+		{
+			name:   "wrong api key",
+			method: download,
+			md:     metadata.Pairs(APIKeyHeader, "other"),
+			msgs:   []proto.Message{&file_svc_v1.DownloadReq{Token: "token"}},
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "download with token",
+			method: download,
+			msgs:   []proto.Message{&file_svc_v1.DownloadReq{Id: "1", Token: "token"}},
+		},
+		{
+			name:   "download without token",
+			method: download,
+			msgs:   []proto.Message{&file_svc_v1.DownloadReq{Id: "1"}},
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "upload header with token",
+			method: upload,
+			msgs: []proto.Message{&file_svc_v1.UploadStreamMsg{
+				Data: &file_svc_v1.UploadStreamMsg_Header{Header: &file_svc_v1.UploadHeader{Token: "token"}},
+			}},
+		},
+		{
+			name:   "upload header without token",
+			method: upload,
+			msgs: []proto.Message{&file_svc_v1.UploadStreamMsg{
+				Data: &file_svc_v1.UploadStreamMsg_Header{Header: &file_svc_v1.UploadHeader{Filename: "a.txt"}},
+			}},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:   "legacy upload starting with a chunk",
+			method: upload,
+			md:     metadata.Pairs(FilenameHeader, "a.txt"),
+			msgs: []proto.Message{&file_svc_v1.UploadStreamMsg{
+				Data: &file_svc_v1.UploadStreamMsg_Chunk{Chunk: []byte("data")},
+			}},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:   "upload closed before the first message",
+			method: upload,
+			md:     metadata.Pairs(FilenameHeader, "a.txt"),
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "unsigned method",
+			method: file_svc_v1.FileService_CreateUploadSession_FullMethodName,
+			msgs:   []proto.Message{&file_svc_v1.DownloadReq{Token: "token"}},
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	interceptor := StreamAuthInterceptor(auth)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := tt.md
+			if md == nil {
+				md = metadata.MD{}
+			}
+			stream := &testStream{
+				ctx:  metadata.NewIncomingContext(context.Background(), md),
+				msgs: tt.msgs,
+			}
+
+			var subject string
+			handler := func(srv any, stream grpc.ServerStream) error {
+				subject = owner(stream.Context())
+				var msg file_svc_v1.UploadStreamMsg
+				if tt.method == download {
+					return stream.RecvMsg(&file_svc_v1.DownloadReq{})
+				}
+				if err := stream.RecvMsg(&msg); err != nil {
+					return err
+				}
+				// later messages are not received after a failed check
+				return stream.RecvMsg(&msg)
+			}
+
+			err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+
+			if tt.code != codes.OK {
+				if status.Code(err) != tt.code {
+					t.Fatalf("got error %v, want %s", err, tt.code)
+				}
+				return
+			}
+			if err != nil && err != io.EOF {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if subject != tt.subject {
+				t.Fatalf("got subject %q, want %q", subject, tt.subject)
+			}
+		})
+	}
+}
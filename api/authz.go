@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"unicode/utf8"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EveryoneGrant is the grants key applying to all callers.
+	EveryoneGrant = "*"
+
+	maxGrants         = 64
+	maxGrantKeyLength = 255
+)
+
+// Action is an operation on a file checked by the Authorizer.
+type Action int
+
+const (
+	ActionCreate Action = iota + 1
+	ActionRead
+	ActionUpdate
+	ActionDelete
+	// ActionShare changes the grants of a file.
+	ActionShare
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionRead:
+		return "read"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	case ActionShare:
+		return "share"
+	}
+	return "unknown"
+}
+
+// Permission is granted to callers other than the file owner.
+type Permission int
+
+const (
+	PermissionNone Permission = iota
+	PermissionRead
+	// PermissionWrite allows to read, update and delete the file.
+	PermissionWrite
+)
+
+// Authorizer decides whether the caller of ctx may perform action on the file.
+// For ActionCreate info describes the file to create. Denied actions should fail
+// with codes.PermissionDenied, or codes.Unauthenticated without a caller identity.
+type Authorizer interface {
+	Authorize(ctx context.Context, action Action, info *FileInfo) error
+}
+
+// SetAuthorizer enables access control, all actions are allowed without an authorizer.
+// It must be called before serving requests.
+func (fsa *FileServiceApi) SetAuthorizer(authorizer Authorizer) {
+	fsa.authorizer = authorizer
+}
+
+func (fsa *FileServiceApi) authorize(ctx context.Context, action Action, info *FileInfo) error {
+	if fsa.authorizer == nil {
+		return nil
+	}
+	if err := fsa.authorizer.Authorize(ctx, action, info); err != nil {
+		return statusError(err, "access denied")
+	}
+	return nil
+}
+
+// checkFile fails when the file is not stored in bucket or the caller may not perform action.
+// Backends without buckets keep all files in the default bucket.
+func (fsa *FileServiceApi) checkFile(ctx context.Context, action Action, bucket, id string) error {
+	if _, ok := fsa.info.(Buckets); !ok && bucket == "" && fsa.authorizer == nil {
+		return nil
+	}
+
+	info, err := fsa.fileInfo(bucket, id)
+	if err != nil {
+		return err
+	}
+
+	return fsa.authorize(ctx, action, info)
+}
+
+// readable removes the files the caller may not read from list.
+// Hidden files of other pages are unknown, so the total is reset when files were removed.
+func (fsa *FileServiceApi) readable(ctx context.Context, list *FileInfoList) (*FileInfoList, error) {
+	if fsa.authorizer == nil {
+		return list, nil
+	}
+
+	files := make([]*FileInfo, 0, len(list.Files))
+	for _, info := range list.Files {
+		err := fsa.authorize(ctx, ActionRead, info)
+		switch status.Code(err) {
+		case codes.OK:
+			files = append(files, info)
+		case codes.PermissionDenied:
+		default:
+			return nil, err
+		}
+	}
+
+	total := list.Total
+	if len(files) < len(list.Files) {
+		total = 0
+	}
+
+	return &FileInfoList{
+		Total:         total,
+		Files:         files,
+		NextPageToken: list.NextPageToken,
+	}, nil
+}
+
+// owner returns the subject of the caller, empty without identity.
+func owner(ctx context.Context) string {
+	if identity, ok := IdentityFromContext(ctx); ok {
+		return identity.Subject
+	}
+	return ""
+}
+
+// createInfo describes the file of meta for ActionCreate.
+func createInfo(meta *UploadMeta) *FileInfo {
+	return &FileInfo{
+		Size:        meta.Size,
+		Filename:    meta.Filename,
+		ContentType: meta.ContentType,
+		Labels:      meta.Labels,
+		Bucket:      meta.Bucket,
+		ExpiresAt:   meta.ExpiresAt,
+		Owner:       meta.Owner,
+		Grants:      meta.Grants,
+	}
+}
+
+func validateGrants(grants map[string]file_svc_v1.Permission) error {
+	if len(grants) > maxGrants {
+		return status.Errorf(codes.InvalidArgument, "file cannot have more than %d grants", maxGrants)
+	}
+
+	for subject, permission := range grants {
+		if subject == "" || len(subject) > maxGrantKeyLength || !utf8.ValidString(subject) {
+			return status.Errorf(codes.InvalidArgument, "grant subject %q is not valid", subject)
+		}
+		switch permission {
+		case file_svc_v1.Permission_PERMISSION_READ, file_svc_v1.Permission_PERMISSION_WRITE:
+		default:
+			return status.Errorf(codes.InvalidArgument, "grant permission of %q is not valid", subject)
+		}
+	}
+
+	return nil
+}
+
+func convertToGrants(grants map[string]file_svc_v1.Permission) map[string]Permission {
+	if grants == nil {
+		return nil
+	}
+	converted := make(map[string]Permission, len(grants))
+	for subject, permission := range grants {
+		converted[subject] = Permission(permission)
+	}
+	return converted
+}
+
+func convertFromGrants(grants map[string]Permission) map[string]file_svc_v1.Permission {
+	if len(grants) == 0 {
+		return nil
+	}
+	converted := make(map[string]file_svc_v1.Permission, len(grants))
+	for subject, permission := range grants {
+		converted[subject] = file_svc_v1.Permission(permission)
+	}
+	return converted
+}
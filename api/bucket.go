@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	minBucketNameLength = 3
+	maxBucketNameLength = 63
+)
+
+// Bucket partitions files. Zero limits are inherited from Settings.
+type Bucket struct {
+	Name         string
+	MaxFileSize  uint32
+	MaxBatchSize uint32
+	CreatedAt    time.Time
+}
+
+// Buckets is an optional Info extension for backends which partition files in buckets.
+// Backends implementing it store UploadMeta.Bucket, return it in FileInfo
+// and filter ListFiles by ListQuery.Bucket. The empty name is the default bucket,
+// which always exists.
+type Buckets interface {
+	CreateBucket(ctx context.Context, bucket *Bucket) error
+	GetBucket(ctx context.Context, name string) (bucket *Bucket, err error)
+	// DeleteBucket should fail with ErrPreconditionFailed while the bucket has files.
+	DeleteBucket(ctx context.Context, name string) error
+	ListBuckets(ctx context.Context) (buckets []*Bucket, err error)
+}
+
+// bucketSettings overrides Settings with the bucket limits.
+type bucketSettings struct {
+	Settings
+	bucket *Bucket
+}
+
+func (bs *bucketSettings) GetBatchSize() uint32 {
+	if bs.bucket.MaxBatchSize > 0 {
+		return bs.bucket.MaxBatchSize
+	}
+	return bs.Settings.GetBatchSize()
+}
+
+func (bs *bucketSettings) GetMaxFileSize() uint32 {
+	if bs.bucket.MaxFileSize > 0 {
+		return bs.bucket.MaxFileSize
+	}
+	return bs.Settings.GetMaxFileSize()
+}
+
+func (fsa *FileServiceApi) CreateBucket(ctx context.Context, req *file_svc_v1.CreateBucketReq) (*file_svc_v1.Bucket, error) {
+
+	buckets, err := fsa.buckets()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBucketName(req.GetName()); err != nil {
+		return nil, err
+	}
+
+	bucket := &Bucket{
+		Name:         req.GetName(),
+		MaxFileSize:  req.GetMaxFileSize(),
+		MaxBatchSize: req.GetMaxBatchSize(),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := buckets.CreateBucket(ctx, bucket); err != nil {
+		return nil, statusError(err, "cannot create bucket")
+	}
+
+	return convertToBucket(bucket), nil
+}
+
+func (fsa *FileServiceApi) DeleteBucket(ctx context.Context, req *file_svc_v1.BucketReq) (*file_svc_v1.DeleteBucketResp, error) {
+
+	buckets, err := fsa.buckets()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "default bucket cannot be deleted")
+	}
+
+	if err := buckets.DeleteBucket(ctx, req.GetName()); err != nil {
+		return nil, statusError(err, "cannot delete bucket")
+	}
+
+	return &file_svc_v1.DeleteBucketResp{}, nil
+}
+
+func (fsa *FileServiceApi) ListBuckets(ctx context.Context, req *file_svc_v1.ListBucketsReq) (*file_svc_v1.ListBucketsResp, error) {
+
+	buckets, err := fsa.buckets()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := buckets.ListBuckets(ctx)
+	if err != nil {
+		return nil, statusError(err, "cannot list buckets")
+	}
+
+	resp := &file_svc_v1.ListBucketsResp{
+		Buckets: make([]*file_svc_v1.Bucket, 0, len(list)),
+	}
+	for _, bucket := range list {
+		resp.Buckets = append(resp.Buckets, convertToBucket(bucket))
+	}
+
+	return resp, nil
+}
+
+func (fsa *FileServiceApi) buckets() (Buckets, error) {
+	buckets, ok := fsa.info.(Buckets)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "buckets are not supported")
+	}
+	return buckets, nil
+}
+
+// bucketSettings returns the settings of the named bucket,
+// failing if the bucket does not exist.
+func (fsa *FileServiceApi) bucketSettings(ctx context.Context, name string) (Settings, error) {
+	if name == "" {
+		return fsa.settings, nil
+	}
+
+	buckets, err := fsa.buckets()
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := buckets.GetBucket(ctx, name)
+	if err != nil {
+		return nil, statusError(err, "cannot get bucket")
+	}
+
+	return &bucketSettings{
+		Settings: fsa.settings,
+		bucket:   bucket,
+	}, nil
+}
+
+// fileInfo returns the info of a file stored in bucket.
+// Files of other buckets and expired files are reported as not found.
+func (fsa *FileServiceApi) fileInfo(bucket, id string) (*FileInfo, error) {
+	if bucket != "" {
+		if _, err := fsa.buckets(); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := fsa.info.GetFileInfo(id)
+	if err != nil {
+		return nil, statusError(err, "cannot get file info")
+	}
+
+	if err := inBucket(info, id, bucket); err != nil {
+		return nil, err
+	}
+
+	if expired(info) {
+		return nil, status.Errorf(codes.NotFound, "file %s is expired", id)
+	}
+
+	return info, nil
+}
+
+func inBucket(info *FileInfo, id, bucket string) error {
+	if info.Bucket != bucket {
+		return status.Errorf(codes.NotFound, "file %s is not found in bucket %q", id, bucket)
+	}
+	return nil
+}
+
+func validateBucketName(name string) error {
+	if len(name) < minBucketNameLength || len(name) > maxBucketNameLength {
+		return status.Errorf(codes.InvalidArgument, "bucket name must be %d to %d characters long",
+			minBucketNameLength, maxBucketNameLength)
+	}
+
+	for i, r := range name {
+		valid := r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' && i > 0 && i < len(name)-1
+		if !valid {
+			return status.Error(codes.InvalidArgument,
+				"bucket name must contain lowercase letters, digits and inner hyphens only")
+		}
+	}
+
+	return nil
+}
+
+func convertToBucket(bucket *Bucket) *file_svc_v1.Bucket {
+	return &file_svc_v1.Bucket{
+		Name:         bucket.Name,
+		MaxFileSize:  bucket.MaxFileSize,
+		MaxBatchSize: bucket.MaxBatchSize,
+		CreatedAt:    convertToTimestamp(bucket.CreatedAt),
+	}
+}
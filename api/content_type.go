@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sniffLength is the number of leading bytes used to detect the content type.
+	sniffLength = 3072
+)
+
+// ContentTypeSettings is an optional Settings extension.
+// When VerifyContentType is true, a content type declared by the client
+// must agree with the detected one.
+type ContentTypeSettings interface {
+	VerifyContentType() bool
+}
+
+// sniffContentType detects the content type of file without consuming it.
+// The returned reader must be used in place of file.
+func sniffContentType(file io.Reader) (*mimetype.MIME, io.Reader, error) {
+	buffered := bufio.NewReaderSize(file, sniffLength)
+
+	head, err := buffered.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, nil, err
+	}
+
+	return mimetype.Detect(head), buffered, nil
+}
+
+// resolveContentType checks the declared content type against the detected one.
+// Types agree when one of them is the same as or more generic than the other,
+// and the more specific one is kept.
+func resolveContentType(declared string, detected *mimetype.MIME, verify bool) (string, error) {
+	if declared == "" {
+		return detected.String(), nil
+	}
+
+	if !verify {
+		return declared, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "content type is not valid: %v", err)
+	}
+
+	if detected.Is(mediaType) {
+		return declared, nil
+	}
+
+	for parent := detected.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Is(mediaType) {
+			return detected.String(), nil
+		}
+	}
+
+	// nothing is known about binary content, and plain text may hold any text format
+	if detected.Parent() == nil || (detected.Is("text/plain") && strings.HasPrefix(mediaType, "text/")) {
+		return declared, nil
+	}
+
+	if known := mimetype.Lookup(mediaType); known != nil {
+		for parent := known.Parent(); parent != nil; parent = parent.Parent() {
+			if parent.Is(detected.String()) {
+				return declared, nil
+			}
+		}
+	}
+
+	return "", status.Errorf(codes.InvalidArgument,
+		"declared content type %q does not match detected %q", declared, detected.String())
+}
+
+func (fsa *FileServiceApi) verifyContentType() bool {
+	settings, ok := fsa.settings.(ContentTypeSettings)
+	return ok && settings.VerifyContentType()
+}
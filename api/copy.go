@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"log/slog"
+	"maps"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+)
+
+func (fsa *FileServiceApi) CopyFile(ctx context.Context, req *file_svc_v1.CopyFileReq) (*file_svc_v1.FileInfoResp, error) {
+
+	sourceID := req.GetSourceId()
+
+	source, err := fsa.fileInfo(req.GetBucket(), sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fsa.authorize(ctx, ActionRead, source); err != nil {
+		return nil, err
+	}
+
+	filename := req.GetFilename()
+	if filename == "" {
+		filename = source.Filename
+	} else if err := validateFilename(filename); err != nil {
+		return nil, err
+	}
+
+	meta := &UploadMeta{
+		Filename:    filename,
+		Size:        source.Size,
+		ContentType: source.ContentType,
+		Checksum:    source.Checksum,
+		Labels:      maps.Clone(source.Labels),
+		Bucket:      source.Bucket,
+		Owner:       owner(ctx),
+	}
+
+	if err := fsa.authorize(ctx, ActionCreate, createInfo(meta)); err != nil {
+		return nil, err
+	}
+
+	var id string
+	if copier, ok := extension[Copier](fsa.svc); ok {
+		id, err = copier.CopyFile(ctx, sourceID, meta)
+	} else {
+		id, err = fsa.copyStream(ctx, sourceID, meta)
+	}
+	if err != nil {
+		return nil, statusError(err, "cannot copy file")
+	}
+
+	info, err := fsa.info.GetFileInfo(id)
+	if err != nil {
+		return nil, statusError(err, "cannot get file info")
+	}
+
+	fsa.log.Info("file copied",
+		logs.Operation("CopyFile"),
+		slog.Int("file_size", int(info.Size)),
+		slog.String("source_id", sourceID),
+		slog.String("id", id),
+	)
+
+	return convertToFileInfo(info), nil
+}
+
+// copyStream copies the file by streaming its contents back into the backend.
+// The copy is deleted when the contents do not match the source checksum.
+func (fsa *FileServiceApi) copyStream(ctx context.Context, sourceID string, meta *UploadMeta) (string, error) {
+
+	file, err := fsa.svc.DownloadStream(ctx, sourceID)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+
+	id, err := fsa.svc.UploadStream(ctx, meta, io.TeeReader(file, hash))
+	if err != nil {
+		return "", err
+	}
+
+	if checksum := hash.Sum(nil); len(meta.Checksum) > 0 && !bytes.Equal(meta.Checksum, checksum) {
+		if err := fsa.svc.DeleteFile(id); err != nil {
+			fsa.log.Error("cannot delete corrupted copy",
+				logs.Operation("CopyFile"),
+				slog.String("id", id),
+				logs.Error(err),
+			)
+		}
+		return "", checksumMismatchError(meta.Checksum, checksum)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxDeleteBatch = 1000
+
+func (fsa *FileServiceApi) DeleteFiles(ctx context.Context, req *file_svc_v1.DeleteFilesReq) (*file_svc_v1.DeleteFilesResp, error) {
+
+	ids := req.GetIds()
+	if len(ids) > maxDeleteBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot delete more than %d files at once", maxDeleteBatch)
+	}
+
+	for _, id := range ids {
+		if id == "" {
+			return nil, status.Error(codes.InvalidArgument, "file id is required")
+		}
+	}
+
+	errs, err := fsa.deleteBucketFiles(ctx, req.GetBucket(), ids)
+	if err != nil {
+		return nil, statusError(err, "cannot delete files")
+	}
+
+	results := make([]*file_svc_v1.DeleteFileResult, 0, len(ids))
+	deleted := 0
+	for i, id := range ids {
+		result := convertToDeleteResult(id, errs[i])
+		if result.GetResult() == file_svc_v1.DeleteResult_DELETE_RESULT_DELETED {
+			deleted++
+		}
+		results = append(results, result)
+	}
+
+	fsa.log.Info("files deleted",
+		logs.Operation("DeleteFiles"),
+		slog.Int("requested", len(ids)),
+		slog.Int("deleted", deleted),
+	)
+
+	return &file_svc_v1.DeleteFilesResp{
+		Results: results,
+	}, nil
+}
+
+// deleteBucketFiles deletes the ids stored in bucket, other ids fail as not found
+// and ids the caller may not delete as permission denied.
+func (fsa *FileServiceApi) deleteBucketFiles(ctx context.Context, bucket string, ids []string) ([]error, error) {
+	if _, ok := fsa.info.(Buckets); !ok && bucket == "" && fsa.authorizer == nil {
+		return fsa.deleteFiles(ctx, ids)
+	}
+
+	errs := make([]error, len(ids))
+
+	var (
+		found []string
+		index []int
+	)
+
+	for i, id := range ids {
+		if err := fsa.checkFile(ctx, ActionDelete, bucket, id); err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return nil, err
+			}
+			errs[i] = err
+			continue
+		}
+		found = append(found, id)
+		index = append(index, i)
+	}
+
+	if len(found) == 0 {
+		return errs, nil
+	}
+
+	deleted, err := fsa.deleteFiles(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range index {
+		errs[i] = deleted[j]
+	}
+
+	return errs, nil
+}
+
+// deleteFile moves the file to the trash when the backend implements Trash
+// and deletes it otherwise.
+func (fsa *FileServiceApi) deleteFile(ctx context.Context, id string) error {
+	if trash, ok := fsa.info.(Trash); ok {
+		return trash.TrashFile(ctx, id, time.Now())
+	}
+	return fsa.svc.DeleteFile(id)
+}
+
+// deleteFiles deletes ids in one call when the backend implements BatchDeleter
+// and one by one otherwise. Files are moved to the trash one by one.
+func (fsa *FileServiceApi) deleteFiles(ctx context.Context, ids []string) ([]error, error) {
+	_, trash := fsa.info.(Trash)
+	if deleter, ok := extension[BatchDeleter](fsa.svc); ok && !trash {
+		errs, err := deleter.DeleteFiles(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(errs) != len(ids) {
+			return nil, status.Errorf(codes.Internal, "backend returned %d results for %d files", len(errs), len(ids))
+		}
+		return errs, nil
+	}
+
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		errs[i] = fsa.deleteFile(ctx, id)
+	}
+	return errs, nil
+}
+
+func convertToDeleteResult(id string, err error) *file_svc_v1.DeleteFileResult {
+	if err == nil {
+		return &file_svc_v1.DeleteFileResult{
+			Id:     id,
+			Result: file_svc_v1.DeleteResult_DELETE_RESULT_DELETED,
+		}
+	}
+
+	st := status.Convert(statusError(err, "cannot delete file"))
+
+	result := file_svc_v1.DeleteResult_DELETE_RESULT_FAILED
+	if st.Code() == codes.NotFound {
+		result = file_svc_v1.DeleteResult_DELETE_RESULT_NOT_FOUND
+	}
+
+	return &file_svc_v1.DeleteFileResult{
+		Id:      id,
+		Result:  result,
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+}
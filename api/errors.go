@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned by backends, so handlers can reply with a matching status code.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrQuotaExceeded      = errors.New("quota exceeded")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+var codesMap = errors.NewErrorsMap(codes.Internal, map[error]codes.Code{
+	ErrNotFound:           codes.NotFound,
+	ErrAlreadyExists:      codes.AlreadyExists,
+	ErrInvalidArgument:    codes.InvalidArgument,
+	ErrQuotaExceeded:      codes.ResourceExhausted,
+	ErrPreconditionFailed: codes.FailedPrecondition,
+})
+
+// statusError converts a backend error to a gRPC status error.
+// Errors which already carry a status are returned as is.
+func statusError(err error, message string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codesMap.Get(err), "%s: %v", message, err)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExpiryInfo is an optional Info extension for backends which store
+// UploadMeta.ExpiresAt and return it in FileInfo.
+type ExpiryInfo interface {
+	// ListExpired returns up to limit files which expired before the given time.
+	ListExpired(ctx context.Context, before time.Time, limit uint32) (files []*FileInfo, err error)
+}
+
+// expiresAt resolves the expiry time of the header, zero if the file does not expire.
+func expiresAt(header *file_svc_v1.UploadHeader) time.Time {
+	switch {
+	case header.GetExpiresAt() != nil:
+		return header.GetExpiresAt().AsTime()
+	case header.GetTtl() != nil:
+		return time.Now().Add(header.GetTtl().AsDuration())
+	}
+	return time.Time{}
+}
+
+func validateExpiry(header *file_svc_v1.UploadHeader) error {
+	if header.GetExpiresAt() != nil && header.GetTtl() != nil {
+		return status.Error(codes.InvalidArgument, "only one of expires at and ttl can be set")
+	}
+
+	if ttl := header.GetTtl(); ttl != nil && ttl.AsDuration() <= 0 {
+		return status.Error(codes.InvalidArgument, "ttl must be positive")
+	}
+
+	if expiresAt := header.GetExpiresAt(); expiresAt != nil && !expiresAt.AsTime().After(time.Now()) {
+		return status.Error(codes.InvalidArgument, "expires at must be in the future")
+	}
+
+	return nil
+}
+
+// checkExpiry fails for expiring files when the backend cannot store the expiry.
+func (fsa *FileServiceApi) checkExpiry(meta *UploadMeta) error {
+	if meta.ExpiresAt.IsZero() {
+		return nil
+	}
+	if _, ok := fsa.info.(ExpiryInfo); !ok {
+		return status.Error(codes.Unimplemented, "file expiry is not supported")
+	}
+	return nil
+}
+
+func expired(info *FileInfo) bool {
+	return !info.ExpiresAt.IsZero() && !info.ExpiresAt.After(time.Now())
+}
+
+// ExpirySweeper deletes expired files.
+type ExpirySweeper struct {
+	*periodic
+	api *FileServiceApi
+	log *slog.Logger
+}
+
+// NewExpirySweeper creates a sweeper looking for expired files every interval, which must be positive.
+func NewExpirySweeper(fsa *FileServiceApi, interval time.Duration) *ExpirySweeper {
+	es := &ExpirySweeper{
+		api: fsa,
+		log: fsa.log.With(logs.Operation("SweepExpired")),
+	}
+	es.periodic = newPeriodic(interval, es.Sweep)
+	return es
+}
+
+// Start sweeps expired files until ctx is done or the sweeper is stopped.
+func (es *ExpirySweeper) Start(ctx context.Context) error {
+	if _, ok := es.api.info.(ExpiryInfo); !ok {
+		return status.Error(codes.Unimplemented, "file expiry is not supported")
+	}
+	return es.periodic.Start(ctx)
+}
+
+// Sweep deletes the files expired so far.
+func (es *ExpirySweeper) Sweep(ctx context.Context) {
+	expiry, ok := es.api.info.(ExpiryInfo)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+
+	deleted := 0
+	for ctx.Err() == nil {
+		files, err := expiry.ListExpired(ctx, now, maxPageSize)
+		if err != nil {
+			es.log.Error("cannot list expired files", logs.Error(err))
+			break
+		}
+
+		failed := 0
+		for _, info := range files {
+			if err := es.api.svc.DeleteFile(info.ID); err != nil {
+				es.log.Error("cannot delete expired file", slog.String("id", info.ID), logs.Error(err))
+				failed++
+				continue
+			}
+			deleted++
+		}
+
+		// failed files would be listed again
+		if len(files) < maxPageSize || failed > 0 {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		es.log.Info("expired files deleted", slog.Int("deleted", deleted))
+	}
+}
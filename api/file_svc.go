@@ -3,11 +3,11 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"io"
 	"log/slog"
 
 	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
-	"github.com/vishenosik/gocherry/pkg/errors"
 	"github.com/vishenosik/gocherry/pkg/logs"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -16,118 +16,265 @@ import (
 
 func (fsa *FileServiceApi) UploadStream(stream file_svc_v1.FileService_UploadStreamServer) error {
 
-	log := fsa.log.With(logs.Operation("UploadStream"))
+	file := newUploadReader(stream, fsa.settings)
 
-	imageData := bytes.Buffer{}
+	header, err := file.receiveHeader()
+	if err != nil {
+		return err
+	}
 
-	var (
-		fileSize    uint32
-		chunksCount int
-	)
+	if header.GetSessionId() != "" {
+		if header.GetToken() != "" {
+			return status.Error(codes.InvalidArgument, "signed uploads cannot use upload sessions")
+		}
+		return fsa.appendUploadSession(stream, file, header)
+	}
+
+	resp, err := fsa.uploadFile(stream.Context(), fsa.log.With(logs.Operation("UploadStream")), header, file)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// Upload stores body described by header with the validation and limits of UploadStream,
+// except for the batch size. Upload sessions are not supported.
+func (fsa *FileServiceApi) Upload(
+	ctx context.Context,
+	header *file_svc_v1.UploadHeader,
+	body io.Reader,
+) (*file_svc_v1.UploadStreamResp, error) {
 
-	md, ok := metadata.FromIncomingContext(stream.Context())
-	if !ok {
-		return status.Errorf(codes.Internal, "cannot get metadata from context")
+	if header.GetSessionId() != "" {
+		return nil, status.Error(codes.InvalidArgument, "upload sessions are not supported")
 	}
 
-	for {
-		req, err := stream.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
+	return fsa.uploadFile(ctx, fsa.log.With(logs.Operation("Upload")), header, newBodyReader(ctx, header, body))
+}
+
+func (fsa *FileServiceApi) uploadFile(
+	ctx context.Context,
+	log *slog.Logger,
+	header *file_svc_v1.UploadHeader,
+	file *uploadReader,
+) (*file_svc_v1.UploadStreamResp, error) {
+
+	signed, err := fsa.signedUpload(header, file.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := fsa.bucketSettings(ctx, header.GetBucket())
+	if err != nil {
+		return nil, err
+	}
+
+	file.limit(settings)
+
+	if err := validateHeader(header, settings); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.checkExpiry(file.meta); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.checkLabels(file.meta); err != nil {
+		return nil, err
+	}
+
+	if !signed {
+		file.meta.Owner = owner(ctx)
+
+		if header.GetFileId() == "" && !header.GetNewVersion() {
+			if err := fsa.authorize(ctx, ActionCreate, createInfo(file.meta)); err != nil {
+				return nil, err
 			}
-			return status.Errorf(codes.Internal, "cannot read chunk: %v", err)
 		}
-		chunk := req.GetChunk()
-		size := len(chunk)
-		fileSize += uint32(size)
+	}
 
-		_, err = imageData.Write(chunk)
-		if err != nil {
-			return status.Errorf(codes.Internal, "cannot write chunk data: %v", err)
-		}
-		chunksCount++
+	detected, reader, err := sniffContentType(file)
+	if file.err != nil {
+		return nil, file.err
+	}
+	if err != nil {
+		return nil, statusError(err, "cannot detect content type")
 	}
 
-	filename := md.Get(FilenameHeader)[0]
+	meta := file.meta
 
-	id, err := fsa.svc.Upload(filename, imageData.Bytes())
+	meta.ContentType, err = resolveContentType(header.GetContentType(), detected, fsa.verifyContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	id, version, err := fsa.store(ctx, header, meta, reader, signed)
+	if file.err != nil {
+		return nil, file.err
+	}
 	if err != nil {
-		return status.Errorf(codes.Internal, "cannot upload file: %v", err)
+		return nil, statusError(err, "cannot upload file")
 	}
 
 	log.Info("file uploaded",
-		slog.Int("file_size", int(fileSize)),
-		slog.Int("chunks_count", chunksCount),
-		slog.String("filename", filename),
+		slog.Int("file_size", int(file.fileSize)),
+		slog.Int("chunks_count", file.chunksCount),
+		slog.String("filename", meta.Filename),
+		slog.String("content_type", meta.ContentType),
+		slog.String("checksum", hex.EncodeToString(meta.Checksum)),
+		slog.Int("version", int(version)),
 		slog.String("id", id),
 	)
 
-	return stream.SendAndClose(&file_svc_v1.UploadStreamResp{
-		Id:   id,
-		Size: fileSize,
-	})
+	return &file_svc_v1.UploadStreamResp{
+		Id:          id,
+		Size:        file.fileSize,
+		ContentType: meta.ContentType,
+		Checksum:    meta.Checksum,
+		Version:     version,
+	}, nil
 }
 
 func (fsa *FileServiceApi) DownloadStream(
-	req *file_svc_v1.FileReq,
+	req *file_svc_v1.DownloadReq,
 	stream file_svc_v1.FileService_DownloadStreamServer,
 ) error {
 
-	var (
-		chunksCount int
+	offset := req.GetOffset()
+	whole := offset == 0 && req.GetLength() == 0
+
+	file, info, err := fsa.Open(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	settings, err := fsa.bucketSettings(stream.Context(), info.Bucket)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&file_svc_v1.DownloadStreamMsg{
+		Info: convertToFileInfo(info),
+	}); err != nil {
+		return err
+	}
+
+	sender := newChunkSender(stream, settings.GetBatchSize())
+
+	if err := sender.send(file); err != nil {
+		return err
+	}
+
+	checksum := sender.checksum()
+
+	if whole && len(info.Checksum) > 0 && !bytes.Equal(info.Checksum, checksum) {
+		return checksumMismatchError(info.Checksum, checksum)
+	}
+
+	stream.SetTrailer(metadata.Pairs(ChecksumTrailer, hex.EncodeToString(checksum)))
+
+	fsa.log.Info("file downloaded",
+		slog.Int("file_size", int(sender.fileSize)),
+		slog.Int("chunks_count", sender.chunksCount),
+		slog.Int("offset", int(offset)),
+		slog.Int("version", int(info.Version)),
+		slog.String("id", info.ID),
 	)
+	return nil
+}
+
+// Stat returns the info of the file requested by req with the checks of DownloadStream.
+func (fsa *FileServiceApi) Stat(ctx context.Context, req *file_svc_v1.DownloadReq) (*FileInfo, error) {
+	_, info, err := fsa.downloadInfo(ctx, req)
+	return info, err
+}
+
+// Open opens the range of the file requested by req with the checks of DownloadStream.
+// Checksums of whole files are not verified.
+func (fsa *FileServiceApi) Open(ctx context.Context, req *file_svc_v1.DownloadReq) (io.ReadCloser, *FileInfo, error) {
+
+	req, info, err := fsa.downloadInfo(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	id := req.GetId()
+	version := req.GetVersion()
+	offset := req.GetOffset()
 
-	file, err := fsa.svc.Download(id)
+	length, err := resolveRange(info.Size, offset, req.GetLength())
 	if err != nil {
-		return status.Errorf(codes.Internal, "cannot download file: %v", err)
+		return nil, nil, err
 	}
 
-	fileReader := bytes.NewBuffer(file)
+	var file io.ReadCloser
+	if offset == 0 && req.GetLength() == 0 {
+		file, err = fsa.openFile(ctx, id, version)
+	} else {
+		file, err = fsa.downloadRange(ctx, id, version, offset, length)
+	}
+	if err != nil {
+		return nil, nil, statusError(err, "cannot download file")
+	}
 
-	buf := make([]byte, fsa.settings.GetBatchSize())
+	return file, info, nil
+}
 
-	for {
-		num, err := fileReader.Read(buf)
-		if err == io.EOF {
-			break
-		}
+// downloadInfo resolves the file of a download and checks the caller may read it.
+// Signed downloads were authorized when the URL was created.
+func (fsa *FileServiceApi) downloadInfo(
+	ctx context.Context,
+	req *file_svc_v1.DownloadReq,
+) (*file_svc_v1.DownloadReq, *FileInfo, error) {
 
-		if err != nil {
-			return err
+	signed := req.GetToken() != ""
+	if signed {
+		var err error
+		if req, err = fsa.signedDownloadReq(req); err != nil {
+			return nil, nil, err
 		}
+	}
 
-		chunk := buf[:num]
+	info, current, err := fsa.versionInfo(ctx, req.GetBucket(), req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if err := stream.Send(&file_svc_v1.DownloadStreamMsg{
-			Chunk: chunk,
-		}); err != nil {
-			return err
+	if !signed {
+		if err := fsa.authorize(ctx, ActionRead, current); err != nil {
+			return nil, nil, err
 		}
-		chunksCount++
 	}
 
-	fsa.log.Info("file downloaded",
-		// slog.Int("file_size", int(fileSize)),
-		slog.Int("chunks_count", chunksCount),
-		slog.String("id", id),
-	)
-	return nil
+	return req, info, nil
 }
 
 func (fsa *FileServiceApi) Constraints(ctx context.Context, req *file_svc_v1.ConstraintsReq) (*file_svc_v1.ConstraintsResp, error) {
+	settings, err := fsa.bucketSettings(ctx, req.GetBucket())
+	if err != nil {
+		return nil, err
+	}
+
 	return &file_svc_v1.ConstraintsResp{
-		MaxBatchSize: fsa.settings.GetBatchSize(),
-		MaxFileSize:  fsa.settings.GetMaxFileSize(),
+		MaxBatchSize: settings.GetBatchSize(),
+		MaxFileSize:  settings.GetMaxFileSize(),
 	}, nil
 }
 
 func (fsa *FileServiceApi) DeleteFile(ctx context.Context, req *file_svc_v1.FileReq) (*file_svc_v1.DeleteFileResp, error) {
-	err := fsa.svc.DeleteFile(req.GetId())
+	if version := req.GetVersion(); version != 0 {
+		return fsa.deleteVersion(ctx, req.GetBucket(), req.GetId(), version)
+	}
+
+	if err := fsa.checkFile(ctx, ActionDelete, req.GetBucket(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	err := fsa.deleteFile(ctx, req.GetId())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot delete file: %v", err)
+		return nil, statusError(err, "cannot delete file")
 	}
 
 	return &file_svc_v1.DeleteFileResp{}, nil
@@ -0,0 +1,500 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serveTest serves fsa over an in-memory connection.
+func serveTest(t *testing.T, fsa *FileServiceApi) file_svc_v1.FileServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	fsa.RegisterService(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return file_svc_v1.NewFileServiceClient(conn)
+}
+
+// uploadChunks sends header and chunks on an upload stream.
+func uploadChunks(
+	ctx context.Context,
+	client file_svc_v1.FileServiceClient,
+	header *file_svc_v1.UploadHeader,
+	chunks ...[]byte,
+) (*file_svc_v1.UploadStreamResp, error) {
+
+	stream, err := client.UploadStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []*file_svc_v1.UploadStreamMsg{{Data: &file_svc_v1.UploadStreamMsg_Header{Header: header}}}
+	for _, chunk := range chunks {
+		msgs = append(msgs, &file_svc_v1.UploadStreamMsg{Data: &file_svc_v1.UploadStreamMsg_Chunk{Chunk: chunk}})
+	}
+
+	for _, msg := range msgs {
+		// the server failed the stream, its error is returned by CloseAndRecv
+		if err := stream.Send(msg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// downloadAll reads a download stream, returning the contents and the checksum trailer.
+func downloadAll(
+	ctx context.Context,
+	client file_svc_v1.FileServiceClient,
+	req *file_svc_v1.DownloadReq,
+) ([]byte, string, error) {
+
+	stream, err := client.DownloadStream(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data []byte
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		data = append(data, msg.GetChunk()...)
+	}
+
+	checksum := stream.Trailer().Get(ChecksumTrailer)
+	if len(checksum) == 0 {
+		return data, "", nil
+	}
+	return data, checksum[0], nil
+}
+
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func TestFileServiceApi_UploadStream_Limits(t *testing.T) {
+	chunk := make([]byte, 1<<10)
+
+	tests := []struct {
+		name   string
+		header *file_svc_v1.UploadHeader
+		chunks [][]byte
+		code   codes.Code
+		reason string
+		limit  string
+	}{
+		{
+			name:   "within limits",
+			header: &file_svc_v1.UploadHeader{Filename: "file.bin"},
+			chunks: [][]byte{chunk, chunk, chunk, chunk},
+		},
+		{
+			name:   "declared size",
+			header: &file_svc_v1.UploadHeader{Filename: "file.bin", Size: 1<<12 + 1},
+			chunks: [][]byte{chunk},
+			code:   codes.ResourceExhausted,
+			reason: ReasonFileTooLarge,
+			limit:  "4096",
+		},
+		{
+			name:   "streamed size",
+			header: &file_svc_v1.UploadHeader{Filename: "file.bin"},
+			chunks: [][]byte{chunk, chunk, chunk, chunk, {0}},
+			code:   codes.ResourceExhausted,
+			reason: ReasonFileTooLarge,
+			limit:  "4096",
+		},
+		{
+			name:   "chunk size",
+			header: &file_svc_v1.UploadHeader{Filename: "file.bin"},
+			chunks: [][]byte{append(chunk, 0)},
+			code:   codes.InvalidArgument,
+			reason: ReasonChunkTooLarge,
+			limit:  "1024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newMemStorage()
+			client := serveTest(t, NewFileServiceStreamApi(storage, storage, testSettings{}))
+
+			_, err := uploadChunks(context.Background(), client, tt.header, tt.chunks...)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got error %v, want %s", err, tt.code)
+			}
+			if tt.code == codes.OK {
+				return
+			}
+
+			info := errorInfo(err)
+			if info == nil {
+				t.Fatalf("error %v has no error info", err)
+			}
+			if info.GetReason() != tt.reason || info.GetDomain() != ErrorDomain {
+				t.Fatalf("got reason %s of %s, want %s of %s", info.GetReason(), info.GetDomain(), tt.reason, ErrorDomain)
+			}
+			if limit := info.GetMetadata()[LimitMetadataKey]; limit != tt.limit {
+				t.Fatalf("got limit %q, want %q", limit, tt.limit)
+			}
+
+			if len(storage.versions) != 0 {
+				t.Fatal("rejected file is stored")
+			}
+		})
+	}
+}
+
+func TestFileServiceApi_Checksum(t *testing.T) {
+	data := []byte("checked contents")
+	sum := sha256.Sum256(data)
+	other := sha256.Sum256([]byte("other contents"))
+
+	storage := newMemStorage()
+	client := serveTest(t, NewFileServiceStreamApi(storage, storage, testSettings{}))
+	ctx := context.Background()
+
+	t.Run("upload mismatch", func(t *testing.T) {
+		header := &file_svc_v1.UploadHeader{Filename: "file.txt", Checksum: other[:]}
+		_, err := uploadChunks(ctx, client, header, data)
+		if code := status.Code(err); code != codes.DataLoss {
+			t.Fatalf("got error %v, want %s", err, codes.DataLoss)
+		}
+		if len(storage.versions) != 0 {
+			t.Fatal("corrupted file is stored")
+		}
+	})
+
+	header := &file_svc_v1.UploadHeader{Filename: "file.txt", Checksum: sum[:]}
+	resp, err := uploadChunks(ctx, client, header, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp.GetChecksum(), sum[:]) {
+		t.Fatalf("got checksum %x, want %x", resp.GetChecksum(), sum)
+	}
+
+	t.Run("download", func(t *testing.T) {
+		got, checksum, err := downloadAll(ctx, client, &file_svc_v1.DownloadReq{Id: resp.GetId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("got contents %q, want %q", got, data)
+		}
+		if checksum != hex.EncodeToString(sum[:]) {
+			t.Fatalf("got checksum trailer %q", checksum)
+		}
+	})
+
+	t.Run("download mismatch", func(t *testing.T) {
+		storage.mu.Lock()
+		storage.versions[resp.GetId()][0].data = []byte("corrupted contents")
+		storage.mu.Unlock()
+
+		_, _, err := downloadAll(ctx, client, &file_svc_v1.DownloadReq{Id: resp.GetId()})
+		if code := status.Code(err); code != codes.DataLoss {
+			t.Fatalf("got error %v, want %s", err, codes.DataLoss)
+		}
+	})
+}
+
+func TestFileServiceApi_UploadSession(t *testing.T) {
+	storage := newMemStorage()
+	client := serveTest(t, NewFileServiceStreamApi(storage, storage, testSettings{}))
+	ctx := context.Background()
+
+	data := []byte("resumable contents")
+	sum := sha256.Sum256(data)
+	first, second := data[:8], data[8:]
+
+	create := func(t *testing.T, checksum []byte) string {
+		t.Helper()
+		session, err := client.CreateUploadSession(ctx, &file_svc_v1.CreateUploadSessionReq{
+			Header: &file_svc_v1.UploadHeader{
+				Filename: "file.txt",
+				Size:     uint32(len(data)),
+				Checksum: checksum,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return session.GetSessionId()
+	}
+
+	appendChunks := func(sessionID string, offset uint32, chunks ...[]byte) (*file_svc_v1.UploadStreamResp, error) {
+		header := &file_svc_v1.UploadHeader{SessionId: sessionID, Offset: offset}
+		return uploadChunks(ctx, client, header, chunks...)
+	}
+
+	committed := func(t *testing.T, sessionID string) uint32 {
+		t.Helper()
+		session, err := client.GetUploadSession(ctx, &file_svc_v1.UploadSessionReq{SessionId: sessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return session.GetCommitted()
+	}
+
+	t.Run("resume and commit", func(t *testing.T) {
+		sessionID := create(t, sum[:])
+
+		// a failed stream keeps the chunks received before the failure
+		_, err := appendChunks(sessionID, 0, first, make([]byte, 1<<10+1))
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Fatalf("got error %v, want %s", err, codes.InvalidArgument)
+		}
+		if got := committed(t, sessionID); got != uint32(len(first)) {
+			t.Fatalf("got %d committed bytes after failure, want %d", got, len(first))
+		}
+
+		if _, err := appendChunks(sessionID, 0, second); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("append at a stale offset got %v, want %s", err, codes.FailedPrecondition)
+		}
+
+		if _, err := client.CommitUpload(ctx, &file_svc_v1.UploadSessionReq{SessionId: sessionID}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("incomplete commit got %v, want %s", err, codes.FailedPrecondition)
+		}
+
+		resp, err := appendChunks(sessionID, committed(t, sessionID), second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetCommitted() != uint32(len(data)) {
+			t.Fatalf("got %d committed bytes, want %d", resp.GetCommitted(), len(data))
+		}
+
+		resp, err = client.CommitUpload(ctx, &file_svc_v1.UploadSessionReq{SessionId: sessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(resp.GetChecksum(), sum[:]) || resp.GetSize() != uint32(len(data)) {
+			t.Fatalf("got size %d and checksum %x", resp.GetSize(), resp.GetChecksum())
+		}
+
+		got, _, err := downloadAll(ctx, client, &file_svc_v1.DownloadReq{Id: resp.GetId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("got contents %q, want %q", got, data)
+		}
+
+		if _, err := client.GetUploadSession(ctx, &file_svc_v1.UploadSessionReq{SessionId: sessionID}); status.Code(err) != codes.NotFound {
+			t.Fatalf("committed session got %v, want %s", err, codes.NotFound)
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		other := sha256.Sum256([]byte("other contents"))
+		sessionID := create(t, other[:])
+
+		if _, err := appendChunks(sessionID, 0, data); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := client.CommitUpload(ctx, &file_svc_v1.UploadSessionReq{SessionId: sessionID})
+		if code := status.Code(err); code != codes.DataLoss {
+			t.Fatalf("got error %v, want %s", err, codes.DataLoss)
+		}
+	})
+
+	t.Run("declared size", func(t *testing.T) {
+		sessionID := create(t, nil)
+
+		_, err := appendChunks(sessionID, 0, data, data)
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Fatalf("got error %v, want %s", err, codes.InvalidArgument)
+		}
+		if got := committed(t, sessionID); got != uint32(len(data)) {
+			t.Fatalf("got %d committed bytes, want %d", got, len(data))
+		}
+	})
+}
+
+func TestFileServiceApi_ListFiles(t *testing.T) {
+	storage := newMemStorage()
+	client := serveTest(t, NewFileServiceStreamApi(storage, storage, testSettings{}))
+	ctx := context.Background()
+
+	for _, file := range []struct {
+		filename string
+		size     int
+	}{
+		{"report-b.txt", 30},
+		{"photo.png", 10},
+		{"report-a.txt", 20},
+		{"report-c.txt", 40},
+	} {
+		header := &file_svc_v1.UploadHeader{Filename: file.filename}
+		if _, err := uploadChunks(ctx, client, header, make([]byte, file.size)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		req       *file_svc_v1.ListFilesReq
+		filenames []string
+		code      codes.Code
+		pageSize  uint32
+	}{
+		{
+			name:      "default page size",
+			req:       &file_svc_v1.ListFilesReq{},
+			filenames: []string{"report-b.txt", "photo.png", "report-a.txt", "report-c.txt"},
+			pageSize:  defaultPageSize,
+		},
+		{
+			name:      "page size cap",
+			req:       &file_svc_v1.ListFilesReq{PageSize: maxPageSize + 1},
+			filenames: []string{"report-b.txt", "photo.png", "report-a.txt", "report-c.txt"},
+			pageSize:  maxPageSize,
+		},
+		{
+			name: "prefix sorted by filename",
+			req: &file_svc_v1.ListFilesReq{
+				FilenamePrefix: "report",
+				SortBy:         file_svc_v1.SortField_SORT_FIELD_FILENAME,
+			},
+			filenames: []string{"report-a.txt", "report-b.txt", "report-c.txt"},
+			pageSize:  defaultPageSize,
+		},
+		{
+			name: "size range sorted by size descending",
+			req: &file_svc_v1.ListFilesReq{
+				MinSize:    20,
+				MaxSize:    30,
+				SortBy:     file_svc_v1.SortField_SORT_FIELD_SIZE,
+				Descending: true,
+			},
+			filenames: []string{"report-b.txt", "report-a.txt"},
+			pageSize:  defaultPageSize,
+		},
+		{
+			name: "unknown sort field",
+			req:  &file_svc_v1.ListFilesReq{SortBy: file_svc_v1.SortField(42)},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "min size above max size",
+			req:  &file_svc_v1.ListFilesReq{MinSize: 30, MaxSize: 20},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid label selector",
+			req:  &file_svc_v1.ListFilesReq{LabelSelector: "env in"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "label selector without label support",
+			req:  &file_svc_v1.ListFilesReq{LabelSelector: "env=prod"},
+			code: codes.Unimplemented,
+		},
+		{
+			name: "invalid page token",
+			req:  &file_svc_v1.ListFilesReq{PageToken: "next"},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListFiles(ctx, tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got error %v, want %s", err, tt.code)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+
+			if got := filenames(resp); !slices.Equal(got, tt.filenames) {
+				t.Fatalf("got files %v, want %v", got, tt.filenames)
+			}
+			if resp.GetTotal() != uint32(len(tt.filenames)) || resp.GetNextPageToken() != "" {
+				t.Fatalf("got total %d and next page %q", resp.GetTotal(), resp.GetNextPageToken())
+			}
+			if storage.query.PageSize != tt.pageSize {
+				t.Fatalf("got page size %d, want %d", storage.query.PageSize, tt.pageSize)
+			}
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		req := &file_svc_v1.ListFilesReq{PageSize: 3, SortBy: file_svc_v1.SortField_SORT_FIELD_FILENAME}
+
+		first, err := client.ListFiles(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.GetNextPageToken() == "" || first.GetTotal() != 4 {
+			t.Fatalf("got total %d and next page %q", first.GetTotal(), first.GetNextPageToken())
+		}
+
+		req.PageToken = first.GetNextPageToken()
+		second, err := client.ListFiles(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if second.GetNextPageToken() != "" {
+			t.Fatalf("last page has next page %q", second.GetNextPageToken())
+		}
+
+		got := append(filenames(first), filenames(second)...)
+		want := []string{"photo.png", "report-a.txt", "report-b.txt", "report-c.txt"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("got files %v, want %v", got, want)
+		}
+	})
+}
+
+func filenames(list *file_svc_v1.ListFilesResp) []string {
+	var names []string
+	for _, info := range list.GetFiles() {
+		names = append(names, info.GetFilename())
+	}
+	return names
+}
@@ -2,45 +2,178 @@ package api
 
 import (
 	"context"
+	"time"
 
 	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
 )
 
 type FileInfo struct {
-	ID       string
-	Size     uint32
-	Filename string
+	ID          string
+	Size        uint32
+	Filename    string
+	ContentType string
+	Checksum    []byte
+	CreatedAt   time.Time
+	Labels      map[string]string
+	Bucket      string
+	// Version is the version number for backends implementing Versioned.
+	Version uint32
+	// DeletedAt is set for files in the trash.
+	DeletedAt time.Time
+	ExpiresAt time.Time
+	Owner     string
+	Grants    map[string]Permission
 }
 
 type FileInfoList struct {
 	Total uint32
 	Files []*FileInfo
+	// NextPageToken is passed as ListQuery.PageToken to get the next page,
+	// it is empty on the last page.
+	NextPageToken string
+}
+
+type SortField int
+
+const (
+	SortByDefault SortField = iota
+	SortByFilename
+	SortBySize
+	SortByCreatedAt
+)
+
+// ListQuery selects a page of files. Zero values of filters are not applied.
+type ListQuery struct {
+	PageSize       uint32
+	PageToken      string
+	FilenamePrefix string
+	MinSize        uint32
+	MaxSize        uint32
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	SortBy         SortField
+	Descending     bool
+	// LabelSelector is only set for backends implementing LabelInfo.
+	LabelSelector LabelSelector
+	// Bucket is only set for backends implementing Buckets.
+	Bucket string
+	// DeletedBefore is only set when listing the trash.
+	DeletedBefore time.Time
 }
 
 func (fsa *FileServiceApi) GetFileInfo(ctx context.Context, req *file_svc_v1.FileReq) (*file_svc_v1.FileInfoResp, error) {
-	info, err := fsa.info.GetFileInfo(req.GetId())
+	info, current, err := fsa.versionInfo(ctx, req.GetBucket(), req.GetId(), req.GetVersion())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot get file info: %v", err)
+		return nil, err
+	}
+
+	if err := fsa.authorize(ctx, ActionRead, current); err != nil {
+		return nil, err
 	}
 
 	return convertToFileInfo(info), nil
 }
 
 func (fsa *FileServiceApi) ListFiles(ctx context.Context, req *file_svc_v1.ListFilesReq) (*file_svc_v1.ListFilesResp, error) {
-	list, err := fsa.info.ListFiles()
+	query, err := convertToListQuery(req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot list files: %v", err)
+		return nil, err
+	}
+
+	// checks the bucket exists
+	if _, err := fsa.bucketSettings(ctx, query.Bucket); err != nil {
+		return nil, err
+	}
+
+	if len(query.LabelSelector) > 0 {
+		if _, err := fsa.labelInfo(); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := fsa.info.ListFiles(query)
+	if err != nil {
+		return nil, statusError(err, "cannot list files")
+	}
+
+	list, err = fsa.readable(ctx, list)
+	if err != nil {
+		return nil, err
 	}
 	return convertToFileInfoList(list), nil
 }
 
+func convertToListQuery(req *file_svc_v1.ListFilesReq) (*ListQuery, error) {
+	query := &ListQuery{
+		PageSize:       req.GetPageSize(),
+		PageToken:      req.GetPageToken(),
+		FilenamePrefix: req.GetFilenamePrefix(),
+		MinSize:        req.GetMinSize(),
+		MaxSize:        req.GetMaxSize(),
+		SortBy:         SortField(req.GetSortBy()),
+		Descending:     req.GetDescending(),
+		Bucket:         req.GetBucket(),
+	}
+
+	switch {
+	case query.PageSize == 0:
+		query.PageSize = defaultPageSize
+	case query.PageSize > maxPageSize:
+		query.PageSize = maxPageSize
+	}
+
+	if query.SortBy < SortByDefault || query.SortBy > SortByCreatedAt {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort field %d", query.SortBy)
+	}
+
+	if query.MaxSize > 0 && query.MinSize > query.MaxSize {
+		return nil, status.Error(codes.InvalidArgument, "min size is greater than max size")
+	}
+
+	if req.GetCreatedAfter() != nil {
+		query.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+
+	if req.GetCreatedBefore() != nil {
+		query.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+
+	if !query.CreatedAfter.IsZero() && !query.CreatedBefore.IsZero() && query.CreatedAfter.After(query.CreatedBefore) {
+		return nil, status.Error(codes.InvalidArgument, "created after is later than created before")
+	}
+
+	selector, err := ParseLabelSelector(req.GetLabelSelector())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "label selector is not valid: %s", status.Convert(err).Message())
+	}
+	query.LabelSelector = selector
+
+	return query, nil
+}
+
 func convertToFileInfo(info *FileInfo) *file_svc_v1.FileInfoResp {
 	return &file_svc_v1.FileInfoResp{
-		Id:       info.ID,
-		Size:     info.Size,
-		Filename: info.Filename,
+		Id:          info.ID,
+		Size:        info.Size,
+		Filename:    info.Filename,
+		ContentType: info.ContentType,
+		Checksum:    info.Checksum,
+		CreatedAt:   convertToTimestamp(info.CreatedAt),
+		Labels:      info.Labels,
+		Bucket:      info.Bucket,
+		Version:     info.Version,
+		DeletedAt:   convertToTimestamp(info.DeletedAt),
+		ExpiresAt:   convertToTimestamp(info.ExpiresAt),
+		Owner:       info.Owner,
+		Grants:      convertFromGrants(info.Grants),
 	}
 }
 
@@ -52,7 +185,15 @@ func convertToFileInfoList(list *FileInfoList) *file_svc_v1.ListFilesResp {
 	}
 
 	return &file_svc_v1.ListFilesResp{
-		Total: list.Total,
-		Files: files,
+		Total:         list.Total,
+		Files:         files,
+		NextPageToken: list.NextPageToken,
+	}
+}
+
+func convertToTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
 	}
+	return timestamppb.New(t)
 }
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// jwtLeeway tolerates clock skew when checking token times.
+const jwtLeeway = time.Minute
+
+// JWTAuthenticator authenticates callers by HS256 signed JWT bearer tokens.
+// Tokens must have the sub and exp claims.
+type JWTAuthenticator struct {
+	secret   []byte
+	issuer   string
+	audience string
+}
+
+// NewJWTAuthenticator creates an authenticator of tokens signed with secret.
+// Empty issuer and audience are not checked.
+func NewJWTAuthenticator(secret []byte, issuer, audience string) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret:   secret,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string    `json:"sub"`
+	Issuer    string    `json:"iss"`
+	Audience  audiences `json:"aud"`
+	ExpiresAt int64     `json:"exp"`
+	NotBefore int64     `json:"nbf"`
+}
+
+// audiences decodes the aud claim, which is a string or an array of strings.
+type audiences []string
+
+func (aud *audiences) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*aud = audiences{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(aud))
+}
+
+func (ja *JWTAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	token, ok := bearerToken(md)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	invalid := status.Error(codes.Unauthenticated, "bearer token is not valid")
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || len(ja.secret) == 0 {
+		return nil, invalid
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, invalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid
+	}
+
+	mac := hmac.New(sha256.New, ja.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, invalid
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, invalid
+	}
+
+	if err := ja.validate(&claims); err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]any)
+	if err := decodeJWTPart(parts[1], &all); err != nil {
+		return nil, invalid
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Claims:  all,
+	}, nil
+}
+
+func (ja *JWTAuthenticator) validate(claims *jwtClaims) error {
+	now := time.Now()
+
+	switch {
+	case claims.Subject == "":
+		return status.Error(codes.Unauthenticated, "bearer token has no subject")
+	case claims.ExpiresAt == 0:
+		return status.Error(codes.Unauthenticated, "bearer token has no expiry")
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(jwtLeeway)):
+		return status.Error(codes.Unauthenticated, "bearer token is expired")
+	case claims.NotBefore != 0 && now.Add(jwtLeeway).Before(time.Unix(claims.NotBefore, 0)):
+		return status.Error(codes.Unauthenticated, "bearer token is not valid yet")
+	case ja.issuer != "" && claims.Issuer != ja.issuer:
+		return status.Error(codes.Unauthenticated, "bearer token issuer is not accepted")
+	case ja.audience != "" && !slices.Contains(claims.Audience, ja.audience):
+		return status.Error(codes.Unauthenticated, "bearer token audience is not accepted")
+	}
+
+	return nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
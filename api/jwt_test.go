@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func signJWT(t *testing.T, secret []byte, header, claims map[string]any) string {
+	t.Helper()
+
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	payload := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	secret := []byte("secret")
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+	now := time.Now()
+
+	claims := func(update func(claims map[string]any)) map[string]any {
+		claims := map[string]any{
+			"sub": "alice",
+			"exp": now.Add(time.Hour).Unix(),
+			"iss": "issuer",
+			"aud": "files",
+		}
+		if update != nil {
+			update(claims)
+		}
+		return claims
+	}
+
+	valid := signJWT(t, secret, hs256, claims(nil))
+
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		subject string
+		noCreds bool
+	}{
+		{
+			name:    "valid",
+			md:      metadata.Pairs(AuthorizationHeader, "Bearer "+valid),
+			subject: "alice",
+		},
+		{
+			name: "audience array",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				c["aud"] = []string{"other", "files"}
+			}))),
+			subject: "alice",
+		},
+		{
+			name:    "no header",
+			md:      metadata.MD{},
+			noCreds: true,
+		},
+		{
+			name:    "other scheme",
+			md:      metadata.Pairs(AuthorizationHeader, "Basic "+valid),
+			noCreds: true,
+		},
+		{
+			name: "tampered claims",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+tamper(t, valid, func(c map[string]any) {
+				c["sub"] = "root"
+			})),
+		},
+		{
+			name: "wrong secret",
+			md:   metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, []byte("other"), hs256, claims(nil))),
+		},
+		{
+			name: "alg none",
+			md:   metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, map[string]any{"alg": "none"}, claims(nil))),
+		},
+		{
+			name: "alg HS512",
+			md:   metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, map[string]any{"alg": "HS512"}, claims(nil))),
+		},
+		{
+			name: "expired",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				c["exp"] = now.Add(-2 * jwtLeeway).Unix()
+			}))),
+		},
+		{
+			name: "no expiry",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				delete(c, "exp")
+			}))),
+		},
+		{
+			name: "no subject",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				delete(c, "sub")
+			}))),
+		},
+		{
+			name: "not valid yet",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				c["nbf"] = now.Add(2 * jwtLeeway).Unix()
+			}))),
+		},
+		{
+			name: "wrong issuer",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				c["iss"] = "other"
+			}))),
+		},
+		{
+			name: "wrong audience",
+			md: metadata.Pairs(AuthorizationHeader, "Bearer "+signJWT(t, secret, hs256, claims(func(c map[string]any) {
+				c["aud"] = "other"
+			}))),
+		},
+		{
+			name: "malformed",
+			md:   metadata.Pairs(AuthorizationHeader, "Bearer abc.def"),
+		},
+	}
+
+	auth := NewJWTAuthenticator(secret, "issuer", "files")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := auth.Authenticate(context.Background(), tt.md)
+
+			switch {
+			case tt.noCreds:
+				if !errors.Is(err, ErrNoCredentials) {
+					t.Fatalf("got error %v, want ErrNoCredentials", err)
+				}
+			case tt.subject == "":
+				if status.Code(err) != codes.Unauthenticated {
+					t.Fatalf("got error %v, want Unauthenticated", err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case identity.Subject != tt.subject:
+				t.Fatalf("got subject %q, want %q", identity.Subject, tt.subject)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_EmptySecret(t *testing.T) {
+	token := signJWT(t, nil, map[string]any{"alg": "HS256"}, map[string]any{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	_, err := NewJWTAuthenticator(nil, "", "").Authenticate(context.Background(), metadata.Pairs(AuthorizationHeader, "Bearer "+token))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got error %v, want Unauthenticated", err)
+	}
+}
+
+// tamper replaces the claims of token keeping its signature.
+func tamper(t *testing.T, token string, update func(claims map[string]any)) string {
+	t.Helper()
+
+	parts := strings.SplitN(token, ".", 3)
+
+	var claims map[string]any
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		t.Fatal(err)
+	}
+	update(claims)
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(data) + "." + parts[2]
+}
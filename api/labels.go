@@ -0,0 +1,298 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxLabels           = 64
+	maxLabelKeyLength   = 63
+	maxLabelValueLength = 255
+)
+
+type LabelOperator int
+
+const (
+	LabelExists LabelOperator = iota
+	LabelNotExists
+	LabelEquals
+	LabelNotEquals
+	LabelIn
+	LabelNotIn
+)
+
+// LabelRequirement is a single condition of a LabelSelector.
+// Equals and NotEquals compare with the only value in Values.
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Values   []string
+}
+
+// LabelSelector matches labels satisfying all of its requirements.
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses a comma separated list of requirements:
+//
+//	key, !key, key=value, key==value, key!=value, key in (a,b), key notin (a,b)
+//
+// Invalid selectors fail with codes.InvalidArgument.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var result LabelSelector
+
+	for _, term := range splitSelector(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return nil, status.Error(codes.InvalidArgument, "empty requirement")
+		}
+
+		requirement, err := parseRequirement(term)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "requirement %q: %s", term, status.Convert(err).Message())
+		}
+
+		result = append(result, requirement)
+	}
+
+	return result, nil
+}
+
+// splitSelector splits selector by commas outside of parentheses.
+func splitSelector(selector string) []string {
+	if strings.TrimSpace(selector) == "" {
+		return nil
+	}
+
+	var (
+		terms []string
+		depth int
+		start int
+	)
+
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(terms, selector[start:])
+}
+
+func parseRequirement(term string) (LabelRequirement, error) {
+
+	if key, ok := strings.CutPrefix(term, "!"); ok {
+		return newRequirement(key, LabelNotExists)
+	}
+
+	if key, value, ok := strings.Cut(term, "!="); ok {
+		return newRequirement(key, LabelNotEquals, value)
+	}
+
+	if key, value, ok := strings.Cut(term, "=="); ok {
+		return newRequirement(key, LabelEquals, value)
+	}
+
+	if key, value, ok := strings.Cut(term, "="); ok {
+		return newRequirement(key, LabelEquals, value)
+	}
+
+	if key, values, ok := cutSetOperator(term, " notin "); ok {
+		return newRequirement(key, LabelNotIn, values...)
+	}
+
+	if key, values, ok := cutSetOperator(term, " in "); ok {
+		return newRequirement(key, LabelIn, values...)
+	}
+
+	return newRequirement(term, LabelExists)
+}
+
+func cutSetOperator(term, operator string) (string, []string, bool) {
+	key, set, ok := strings.Cut(term, operator)
+	if !ok {
+		return "", nil, false
+	}
+
+	set = strings.TrimSpace(set)
+	if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+		return "", nil, false
+	}
+
+	values := strings.Split(set[1:len(set)-1], ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	return key, values, true
+}
+
+func newRequirement(key string, operator LabelOperator, values ...string) (LabelRequirement, error) {
+	key = strings.TrimSpace(key)
+	if err := validateLabelKey(key); err != nil {
+		return LabelRequirement{}, err
+	}
+
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+		if err := validateLabelValue(key, values[i]); err != nil {
+			return LabelRequirement{}, err
+		}
+	}
+
+	return LabelRequirement{
+		Key:      key,
+		Operator: operator,
+		Values:   values,
+	}, nil
+}
+
+// Matches reports whether labels satisfy every requirement of the selector.
+func (ls LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range ls {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (lr LabelRequirement) Matches(labels map[string]string) bool {
+	value, ok := labels[lr.Key]
+
+	switch lr.Operator {
+	case LabelExists:
+		return ok
+	case LabelNotExists:
+		return !ok
+	case LabelEquals, LabelIn:
+		return ok && lr.hasValue(value)
+	case LabelNotEquals, LabelNotIn:
+		return !ok || !lr.hasValue(value)
+	}
+	return false
+}
+
+func (lr LabelRequirement) hasValue(value string) bool {
+	for _, v := range lr.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateLabels(labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return status.Errorf(codes.InvalidArgument, "file cannot have more than %d labels", maxLabels)
+	}
+
+	for key, value := range labels {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+		if err := validateLabelValue(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateLabelKey allows keys which cannot be confused with selector syntax.
+func validateLabelKey(key string) error {
+	switch {
+	case key == "":
+		return status.Error(codes.InvalidArgument, "label key is required")
+	case len(key) > maxLabelKeyLength:
+		return status.Errorf(codes.InvalidArgument, "label key %q is longer than %d bytes", key, maxLabelKeyLength)
+	case !isAlphanumeric(rune(key[0])):
+		return status.Errorf(codes.InvalidArgument, "label key %q must start with a letter or digit", key)
+	}
+
+	for _, r := range key {
+		if !isAlphanumeric(r) && !strings.ContainsRune("-_./", r) {
+			return status.Errorf(codes.InvalidArgument, "label key %q contains invalid character %q", key, r)
+		}
+	}
+
+	return nil
+}
+
+func validateLabelValue(key, value string) error {
+	switch {
+	case len(value) > maxLabelValueLength:
+		return status.Errorf(codes.InvalidArgument, "label %q: value is longer than %d bytes", key, maxLabelValueLength)
+	case !utf8.ValidString(value):
+		return status.Errorf(codes.InvalidArgument, "label %q: value is not valid UTF-8", key)
+	case strings.ContainsAny(value, ",()=!"):
+		return status.Errorf(codes.InvalidArgument, "label %q: value must not contain selector characters", key)
+	}
+	return nil
+}
+
+func isAlphanumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func (fsa *FileServiceApi) UpdateFileLabels(
+	ctx context.Context,
+	req *file_svc_v1.UpdateFileLabelsReq,
+) (*file_svc_v1.FileInfoResp, error) {
+
+	labelInfo, err := fsa.labelInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLabels(req.GetLabels()); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.checkFile(ctx, ActionUpdate, req.GetBucket(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	for _, key := range req.GetRemove() {
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := labelInfo.UpdateFileLabels(ctx, req.GetId(), req.GetLabels(), req.GetRemove())
+	if err != nil {
+		return nil, statusError(err, "cannot update file labels")
+	}
+
+	return convertToFileInfo(info), nil
+}
+
+// checkLabels fails for labeled files when the backend cannot store labels.
+func (fsa *FileServiceApi) checkLabels(meta *UploadMeta) error {
+	if len(meta.Labels) == 0 {
+		return nil
+	}
+	_, err := fsa.labelInfo()
+	return err
+}
+
+func (fsa *FileServiceApi) labelInfo() (LabelInfo, error) {
+	labelInfo, ok := fsa.info.(LabelInfo)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "labels are not supported")
+	}
+	return labelInfo, nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     LabelSelector
+		wantErr  bool
+	}{
+		{selector: "", want: nil},
+		{selector: "  ", want: nil},
+		{selector: "tenant", want: LabelSelector{{Key: "tenant", Operator: LabelExists}}},
+		{selector: "!draft", want: LabelSelector{{Key: "draft", Operator: LabelNotExists}}},
+		{selector: "tenant=acme", want: LabelSelector{{Key: "tenant", Operator: LabelEquals, Values: []string{"acme"}}}},
+		{selector: "tenant==acme", want: LabelSelector{{Key: "tenant", Operator: LabelEquals, Values: []string{"acme"}}}},
+		{selector: "tenant!=acme", want: LabelSelector{{Key: "tenant", Operator: LabelNotEquals, Values: []string{"acme"}}}},
+		{selector: "tenant=", want: LabelSelector{{Key: "tenant", Operator: LabelEquals, Values: []string{""}}}},
+		{
+			selector: "kind in (invoice, receipt)",
+			want:     LabelSelector{{Key: "kind", Operator: LabelIn, Values: []string{"invoice", "receipt"}}},
+		},
+		{
+			selector: "kind notin (draft)",
+			want:     LabelSelector{{Key: "kind", Operator: LabelNotIn, Values: []string{"draft"}}},
+		},
+		{
+			selector: " tenant = acme , kind in (a,b), !draft ",
+			want: LabelSelector{
+				{Key: "tenant", Operator: LabelEquals, Values: []string{"acme"}},
+				{Key: "kind", Operator: LabelIn, Values: []string{"a", "b"}},
+				{Key: "draft", Operator: LabelNotExists},
+			},
+		},
+		{selector: "tenant,", wantErr: true},
+		{selector: ",tenant", wantErr: true},
+		{selector: "a,,b", wantErr: true},
+		{selector: "=acme", wantErr: true},
+		{selector: "!", wantErr: true},
+		{selector: "kind in (a", wantErr: true},
+		{selector: "kind in a,b", wantErr: true},
+		{selector: "bad key=x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseLabelSelector(tt.selector)
+
+			if tt.wantErr {
+				if code := status.Code(err); code != codes.InvalidArgument {
+					t.Fatalf("got selector %+v and error %v, want %s", got, err, codes.InvalidArgument)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got selector %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := map[string]string{"tenant": "acme", "kind": "invoice"}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "tenant", want: true},
+		{selector: "draft", want: false},
+		{selector: "!draft", want: true},
+		{selector: "!tenant", want: false},
+		{selector: "tenant=acme", want: true},
+		{selector: "tenant=other", want: false},
+		{selector: "tenant!=other", want: true},
+		{selector: "draft!=x", want: true},
+		{selector: "kind in (invoice,receipt)", want: true},
+		{selector: "kind in (receipt)", want: false},
+		{selector: "draft in (x)", want: false},
+		{selector: "kind notin (receipt)", want: true},
+		{selector: "draft notin (x)", want: true},
+		{selector: "tenant=acme,kind=receipt", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseLabelSelector(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := selector.Matches(labels); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileServiceApi_Upload_Labels(t *testing.T) {
+	storage := newMemStorage()
+	fsa := NewFileServiceStreamApi(storage, storage, testSettings{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		code   codes.Code
+	}{
+		{name: "without labels"},
+		{name: "labels without label support", labels: map[string]string{"env": "prod"}, code: codes.Unimplemented},
+		{name: "invalid key", labels: map[string]string{"-env": "prod"}, code: codes.InvalidArgument},
+		{name: "invalid value", labels: map[string]string{"env": "a,b"}, code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := &file_svc_v1.UploadHeader{Filename: "report.txt", Labels: tt.labels}
+			_, err := fsa.Upload(ctx, header, strings.NewReader("data"))
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got error %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
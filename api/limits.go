@@ -0,0 +1,56 @@
+package api
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorDomain is the domain of errdetails.ErrorInfo attached to API errors.
+	ErrorDomain = "file_svc.v1"
+
+	ReasonFileTooLarge  = "FILE_TOO_LARGE"
+	ReasonChunkTooLarge = "CHUNK_TOO_LARGE"
+
+	// LimitMetadataKey holds the exceeded limit in errdetails.ErrorInfo metadata.
+	LimitMetadataKey = "limit"
+)
+
+// FileTooLargeError is the status error of a file exceeding maxFileSize.
+func FileTooLargeError(maxFileSize uint32) error {
+	return limitError(
+		codes.ResourceExhausted,
+		ReasonFileTooLarge,
+		maxFileSize,
+		"file size exceeds the limit of %d bytes",
+	)
+}
+
+func chunkTooLargeError(batchSize uint32) error {
+	return limitError(
+		codes.InvalidArgument,
+		ReasonChunkTooLarge,
+		batchSize,
+		"chunk size exceeds the limit of %d bytes",
+	)
+}
+
+func limitError(code codes.Code, reason string, limit uint32, format string) error {
+	st := status.Newf(code, format, limit)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			LimitMetadataKey: strconv.FormatUint(uint64(limit), 10),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vishenosik/gocherry/pkg/errors"
+)
+
+// periodic runs task every interval between Start and Stop.
+type periodic struct {
+	interval time.Duration
+	task     func(ctx context.Context)
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	stop    chan struct{}
+	// done is closed when Start returns.
+	done chan struct{}
+}
+
+func newPeriodic(interval time.Duration, task func(ctx context.Context)) *periodic {
+	return &periodic{
+		interval: interval,
+		task:     task,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the task until ctx is done or Stop is called.
+// It returns at once when Stop was called before.
+func (p *periodic) Start(ctx context.Context) error {
+	if p.interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", p.interval)
+	}
+
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return errors.New("already started")
+	}
+	p.started = true
+	p.mu.Unlock()
+
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+			p.task(ctx)
+		}
+	}
+}
+
+// Stop waits for the running task to finish.
+func (p *periodic) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.stop)
+	}
+	started := p.started
+	p.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+func TestPeriodic_StartStop(t *testing.T) {
+	var runs atomic.Int32
+	p := newPeriodic(time.Millisecond, func(context.Context) { runs.Add(1) })
+
+	started := make(chan error, 1)
+	go func() { started <- p.Start(context.Background()) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runs.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("task did not run")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := p.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := runs.Load()
+	time.Sleep(5 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Fatal("task runs after Stop")
+	}
+}
+
+func TestPeriodic_StopBeforeStart(t *testing.T) {
+	p := newPeriodic(time.Hour, func(context.Context) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Start and Stop race when a server shuts down while starting
+	stopped := make(chan error, 1)
+	go func() { stopped <- p.Stop(ctx) }()
+
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("Start did not return after Stop")
+	}
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPeriodic_Start_Twice(t *testing.T) {
+	p := newPeriodic(time.Hour, func(context.Context) {})
+	if err := p.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Start(context.Background()); err == nil {
+		t.Fatal("second Start got no error")
+	}
+}
+
+func TestPeriodic_Start_Interval(t *testing.T) {
+	p := newPeriodic(0, func(context.Context) {})
+
+	err := p.Start(context.Background())
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if _, ok := status.FromError(err); ok {
+		t.Fatalf("got RPC status %v, the interval is not a request argument", err)
+	}
+}
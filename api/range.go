@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type rangeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// resolveRange validates the requested range against the file size.
+// Zero length is resolved to the rest of the file.
+func resolveRange(size, offset, length uint32) (uint32, error) {
+	if offset > size {
+		return 0, status.Errorf(codes.OutOfRange, "offset %d is beyond the file size of %d bytes", offset, size)
+	}
+
+	if rest := size - offset; length == 0 || length > rest {
+		length = rest
+	}
+
+	return length, nil
+}
+
+// downloadRange opens length bytes of the file starting at offset.
+// Backends without RangeFileService and previous versions are read
+// from the start and the leading bytes are skipped.
+func (fsa *FileServiceApi) downloadRange(ctx context.Context, id string, version, offset, length uint32) (io.ReadCloser, error) {
+	if ranged, ok := extension[RangeFileService](fsa.svc); ok && version == 0 {
+		return ranged.DownloadRange(ctx, id, offset, length)
+	}
+
+	file, err := fsa.openFile(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.CopyN(io.Discard, file, int64(offset)); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &rangeReadCloser{
+		Reader: io.LimitReader(file, int64(length)),
+		Closer: file,
+	}, nil
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"log/slog"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (fsa *FileServiceApi) CreateUploadSession(
+	ctx context.Context,
+	req *file_svc_v1.CreateUploadSessionReq,
+) (*file_svc_v1.UploadSession, error) {
+
+	sessions, err := fsa.uploadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	header := req.GetHeader()
+
+	settings, err := fsa.bucketSettings(ctx, header.GetBucket())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateHeader(header, settings); err != nil {
+		return nil, err
+	}
+
+	if header.GetFileId() != "" || header.GetNewVersion() {
+		return nil, status.Error(codes.InvalidArgument, "versions cannot be uploaded through upload sessions")
+	}
+
+	if header.GetToken() != "" {
+		return nil, status.Error(codes.InvalidArgument, "signed uploads cannot use upload sessions")
+	}
+
+	meta := uploadMeta(header)
+	meta.Owner = owner(ctx)
+
+	if err := fsa.checkExpiry(meta); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.checkLabels(meta); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.authorize(ctx, ActionCreate, createInfo(meta)); err != nil {
+		return nil, err
+	}
+
+	id, err := sessions.CreateUploadSession(ctx, meta)
+	if err != nil {
+		return nil, statusError(err, "cannot create upload session")
+	}
+
+	return convertToUploadSession(&UploadSession{
+		ID:   id,
+		Meta: meta,
+	}), nil
+}
+
+func (fsa *FileServiceApi) GetUploadSession(
+	ctx context.Context,
+	req *file_svc_v1.UploadSessionReq,
+) (*file_svc_v1.UploadSession, error) {
+
+	sessions, err := fsa.uploadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := sessions.GetUploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, statusError(err, "cannot get upload session")
+	}
+
+	if err := fsa.authorizeSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return convertToUploadSession(session), nil
+}
+
+// CommitUpload verifies the bytes collected by the session and turns them into a file.
+func (fsa *FileServiceApi) CommitUpload(
+	ctx context.Context,
+	req *file_svc_v1.UploadSessionReq,
+) (*file_svc_v1.UploadStreamResp, error) {
+
+	log := fsa.log.With(logs.Operation("CommitUpload"))
+
+	sessions, err := fsa.uploadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID := req.GetSessionId()
+
+	session, err := sessions.GetUploadSession(ctx, sessionID)
+	if err != nil {
+		return nil, statusError(err, "cannot get upload session")
+	}
+
+	if err := fsa.authorizeSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	if declared := session.Meta.Size; declared > 0 && session.Committed != declared {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"committed %d bytes, declared size is %d bytes", session.Committed, declared)
+	}
+
+	meta, err := fsa.verifyUploadSession(ctx, sessions, session)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := sessions.CommitUploadSession(ctx, sessionID, meta)
+	if err != nil {
+		return nil, statusError(err, "cannot commit upload session")
+	}
+
+	log.Info("file uploaded",
+		slog.Int("file_size", int(session.Committed)),
+		slog.String("filename", meta.Filename),
+		slog.String("session_id", sessionID),
+		slog.String("id", id),
+	)
+
+	return &file_svc_v1.UploadStreamResp{
+		Id:          id,
+		Size:        session.Committed,
+		ContentType: meta.ContentType,
+		Checksum:    meta.Checksum,
+		Committed:   session.Committed,
+	}, nil
+}
+
+// verifyUploadSession reads the session bytes back to detect the content type
+// and check the declared checksum.
+func (fsa *FileServiceApi) verifyUploadSession(
+	ctx context.Context,
+	sessions UploadSessionService,
+	session *UploadSession,
+) (*UploadMeta, error) {
+
+	file, err := sessions.ReadUploadSession(ctx, session.ID)
+	if err != nil {
+		return nil, statusError(err, "cannot read upload session")
+	}
+	defer file.Close()
+
+	detected, reader, err := sniffContentType(file)
+	if err != nil {
+		return nil, statusError(err, "cannot detect content type")
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return nil, statusError(err, "cannot read upload session")
+	}
+
+	meta := *session.Meta
+
+	meta.ContentType, err = resolveContentType(meta.ContentType, detected, fsa.verifyContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := hash.Sum(nil)
+
+	if len(meta.Checksum) > 0 && !bytes.Equal(meta.Checksum, checksum) {
+		return nil, checksumMismatchError(meta.Checksum, checksum)
+	}
+
+	meta.Checksum = checksum
+	return &meta, nil
+}
+
+// appendUploadSession stores the upload stream in the session given by header.
+func (fsa *FileServiceApi) appendUploadSession(
+	stream file_svc_v1.FileService_UploadStreamServer,
+	file *uploadReader,
+	header *file_svc_v1.UploadHeader,
+) error {
+
+	log := fsa.log.With(logs.Operation("UploadStream"))
+
+	sessions, err := fsa.uploadSessions()
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	sessionID := header.GetSessionId()
+	offset := header.GetOffset()
+
+	session, err := sessions.GetUploadSession(ctx, sessionID)
+	if err != nil {
+		return statusError(err, "cannot get upload session")
+	}
+
+	if err := fsa.authorizeSession(ctx, session); err != nil {
+		return err
+	}
+
+	if offset != session.Committed {
+		return status.Errorf(codes.FailedPrecondition,
+			"offset %d does not match %d committed bytes", offset, session.Committed)
+	}
+
+	settings, err := fsa.bucketSettings(ctx, session.Meta.Bucket)
+	if err != nil {
+		return err
+	}
+
+	file.limit(settings)
+	file.resume(session)
+
+	err = sessions.AppendUploadSession(ctx, sessionID, offset, file)
+	if file.err != nil {
+		return file.err
+	}
+	if err != nil {
+		return statusError(err, "cannot append upload session")
+	}
+
+	session, err = sessions.GetUploadSession(ctx, sessionID)
+	if err != nil {
+		return statusError(err, "cannot get upload session")
+	}
+
+	log.Info("upload session appended",
+		slog.Int("offset", int(offset)),
+		slog.Int("committed", int(session.Committed)),
+		slog.Int("chunks_count", file.chunksCount),
+		slog.String("session_id", sessionID),
+	)
+
+	return stream.SendAndClose(&file_svc_v1.UploadStreamResp{
+		Size:      file.fileSize - offset,
+		Committed: session.Committed,
+	})
+}
+
+// authorizeSession checks the caller may continue the upload, as an update of the file to create.
+func (fsa *FileServiceApi) authorizeSession(ctx context.Context, session *UploadSession) error {
+	return fsa.authorize(ctx, ActionUpdate, createInfo(session.Meta))
+}
+
+func (fsa *FileServiceApi) uploadSessions() (UploadSessionService, error) {
+	sessions, ok := extension[UploadSessionService](fsa.svc)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "upload sessions are not supported")
+	}
+	return sessions, nil
+}
+
+func convertToUploadSession(session *UploadSession) *file_svc_v1.UploadSession {
+	return &file_svc_v1.UploadSession{
+		SessionId: session.ID,
+		Committed: session.Committed,
+		Filename:  session.Meta.Filename,
+		Size:      session.Meta.Size,
+		Bucket:    session.Meta.Bucket,
+	}
+}
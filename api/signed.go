@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSignedURLExpiry = 15 * time.Minute
+	maxSignedURLExpiry     = 7 * 24 * time.Hour
+)
+
+// SigningKey is a secret signing URL tokens, ID selects it when tokens are verified.
+type SigningKey struct {
+	ID     string
+	Secret []byte
+}
+
+// SigningSettings is an optional Settings extension enabling signed URLs.
+// The first key signs new tokens and all keys verify them, so keys are rotated
+// by prepending a new key and removing the old one once its tokens expired.
+type SigningSettings interface {
+	GetSigningKeys() []SigningKey
+	// GetSignedURLBase is the base URL of the HTTP gateway, signed URLs are relative when empty.
+	GetSignedURLBase() string
+}
+
+// SignedToken is the verified content of a signed URL token.
+type SignedToken struct {
+	Method file_svc_v1.SignedMethod
+	// Subject is the caller which created the URL, it owns files uploaded with it.
+	Subject string
+	// ID is empty for upload tokens creating new files.
+	ID        string
+	Bucket    string
+	Version   uint32
+	ExpiresAt time.Time
+}
+
+// tokenClaims is the signed payload of a token.
+type tokenClaims struct {
+	KeyID   string `json:"kid"`
+	Method  int32  `json:"m"`
+	Subject string `json:"sub,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Bucket  string `json:"b,omitempty"`
+	Version uint32 `json:"v,omitempty"`
+	Expires int64  `json:"exp"`
+}
+
+func (fsa *FileServiceApi) CreateSignedURL(ctx context.Context, req *file_svc_v1.CreateSignedURLReq) (*file_svc_v1.SignedURL, error) {
+
+	settings, err := fsa.signingSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := settings.GetSigningKeys()
+	if len(keys) == 0 || len(keys[0].Secret) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "signing keys are not configured")
+	}
+
+	expiry := defaultSignedURLExpiry
+	if req.GetExpiresIn() != nil {
+		expiry = req.GetExpiresIn().AsDuration()
+	}
+	if expiry <= 0 || expiry > maxSignedURLExpiry {
+		return nil, status.Errorf(codes.InvalidArgument, "expiry must be positive and at most %s", maxSignedURLExpiry)
+	}
+
+	if err := fsa.checkSignedTarget(ctx, req); err != nil {
+		return nil, err
+	}
+
+	signed := &SignedToken{
+		Method:    req.GetMethod(),
+		Subject:   owner(ctx),
+		ID:        req.GetId(),
+		Bucket:    req.GetBucket(),
+		Version:   req.GetVersion(),
+		ExpiresAt: time.Now().Add(expiry).Truncate(time.Second),
+	}
+
+	token, err := signToken(keys[0], signed)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot sign token: %v", err)
+	}
+
+	path := "/files"
+	if signed.Method == file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD {
+		path += "/" + url.PathEscape(signed.ID)
+	}
+
+	fsa.log.Info("signed url created",
+		logs.Operation("CreateSignedURL"),
+		slog.String("method", signed.Method.String()),
+		slog.String("id", signed.ID),
+		slog.String("key_id", keys[0].ID),
+		slog.Time("expires_at", signed.ExpiresAt),
+	)
+
+	return &file_svc_v1.SignedURL{
+		Token:     token,
+		Url:       strings.TrimSuffix(settings.GetSignedURLBase(), "/") + path + "?token=" + url.QueryEscape(token),
+		ExpiresAt: timestamppb.New(signed.ExpiresAt),
+	}, nil
+}
+
+// checkSignedTarget checks the file or bucket of a signed URL exists
+// and the caller may perform the action the URL grants.
+func (fsa *FileServiceApi) checkSignedTarget(ctx context.Context, req *file_svc_v1.CreateSignedURLReq) error {
+	switch req.GetMethod() {
+	case file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD:
+		if req.GetId() == "" {
+			return status.Error(codes.InvalidArgument, "file id is required")
+		}
+		_, current, err := fsa.versionInfo(ctx, req.GetBucket(), req.GetId(), req.GetVersion())
+		if err != nil {
+			return err
+		}
+		return fsa.authorize(ctx, ActionRead, current)
+
+	case file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD:
+		if req.GetVersion() != 0 {
+			return status.Error(codes.InvalidArgument, "upload urls cannot be pinned to a version")
+		}
+		if req.GetId() == "" {
+			if _, err := fsa.bucketSettings(ctx, req.GetBucket()); err != nil {
+				return err
+			}
+			return fsa.authorize(ctx, ActionCreate, &FileInfo{
+				Bucket: req.GetBucket(),
+				Owner:  owner(ctx),
+			})
+		}
+		if _, err := fsa.versioned(); err != nil {
+			return err
+		}
+		return fsa.checkFile(ctx, ActionUpdate, req.GetBucket(), req.GetId())
+	}
+
+	return status.Error(codes.InvalidArgument, "signed url method is required")
+}
+
+// VerifyToken checks the signature and expiry of a token created by CreateSignedURL
+// and that it was signed for method.
+func (fsa *FileServiceApi) VerifyToken(token string, method file_svc_v1.SignedMethod) (*SignedToken, error) {
+
+	settings, err := fsa.signingSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := verifyToken(settings.GetSigningKeys(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	if signed.Method != method {
+		return nil, status.Error(codes.PermissionDenied, "signed token is not valid for this method")
+	}
+
+	return signed, nil
+}
+
+// signedUpload applies the upload token of header to header and meta, reporting whether
+// the upload is signed. The file is owned by the URL creator and gets no grants of the uploader.
+func (fsa *FileServiceApi) signedUpload(header *file_svc_v1.UploadHeader, meta *UploadMeta) (bool, error) {
+	if header.GetToken() == "" {
+		return false, nil
+	}
+
+	signed, err := fsa.VerifyToken(header.GetToken(), file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD)
+	if err != nil {
+		return false, err
+	}
+
+	header.Bucket = signed.Bucket
+	header.FileId = signed.ID
+	header.NewVersion = false
+
+	meta.Bucket = signed.Bucket
+	meta.Owner = signed.Subject
+	meta.Grants = nil
+
+	return true, nil
+}
+
+// signedDownloadReq replaces the file of req with the file of its token.
+func (fsa *FileServiceApi) signedDownloadReq(req *file_svc_v1.DownloadReq) (*file_svc_v1.DownloadReq, error) {
+
+	signed, err := fsa.VerifyToken(req.GetToken(), file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD)
+	if err != nil {
+		return nil, err
+	}
+
+	if id := req.GetId(); id != "" && id != signed.ID {
+		return nil, status.Error(codes.PermissionDenied, "signed token is not valid for this file")
+	}
+
+	return &file_svc_v1.DownloadReq{
+		Id:      signed.ID,
+		Bucket:  signed.Bucket,
+		Version: signed.Version,
+		Offset:  req.GetOffset(),
+		Length:  req.GetLength(),
+	}, nil
+}
+
+func (fsa *FileServiceApi) signingSettings() (SigningSettings, error) {
+	settings, ok := fsa.settings.(SigningSettings)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "signed urls are not supported")
+	}
+	return settings, nil
+}
+
+// signToken encodes the token as base64url(claims) "." base64url(HMAC-SHA256(claims)).
+func signToken(key SigningKey, signed *SignedToken) (string, error) {
+	claims, err := json.Marshal(tokenClaims{
+		KeyID:   key.ID,
+		Method:  int32(signed.Method),
+		Subject: signed.Subject,
+		ID:      signed.ID,
+		Bucket:  signed.Bucket,
+		Version: signed.Version,
+		Expires: signed.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(tokenMAC(key.Secret, payload)), nil
+}
+
+func verifyToken(keys []SigningKey, token string) (*SignedToken, error) {
+	invalid := status.Error(codes.Unauthenticated, "signed token is not valid")
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, invalid
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, invalid
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		return nil, invalid
+	}
+
+	key, ok := signingKey(keys, claims.KeyID)
+	if !ok || !hmac.Equal(mac, tokenMAC(key.Secret, payload)) {
+		return nil, invalid
+	}
+
+	expiresAt := time.Unix(claims.Expires, 0)
+	if !expiresAt.After(time.Now()) {
+		return nil, status.Error(codes.Unauthenticated, "signed token is expired")
+	}
+
+	return &SignedToken{
+		Method:    file_svc_v1.SignedMethod(claims.Method),
+		Subject:   claims.Subject,
+		ID:        claims.ID,
+		Bucket:    claims.Bucket,
+		Version:   claims.Version,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func signingKey(keys []SigningKey, id string) (SigningKey, bool) {
+	for _, key := range keys {
+		if key.ID == id && len(key.Secret) > 0 {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+func tokenMAC(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyToken(t *testing.T) {
+	current := SigningKey{ID: "k2", Secret: []byte("current")}
+	previous := SigningKey{ID: "k1", Secret: []byte("previous")}
+	keys := []SigningKey{current, previous}
+
+	signed := &SignedToken{
+		Method:    file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD,
+		Subject:   "alice",
+		ID:        "1",
+		Bucket:    "docs",
+		Version:   2,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	sign := func(key SigningKey, signed *SignedToken) string {
+		token, err := signToken(key, signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	valid := sign(current, signed)
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	expired := *signed
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+
+	otherFile := *signed
+	otherFile.ID = "2"
+	_, otherSignature, _ := strings.Cut(sign(current, &otherFile), ".")
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "current key", token: valid, valid: true},
+		{name: "previous key", token: sign(previous, signed), valid: true},
+		{name: "unknown kid", token: sign(SigningKey{ID: "k3", Secret: current.Secret}, signed)},
+		{name: "kid of another key", token: sign(SigningKey{ID: previous.ID, Secret: current.Secret}, signed)},
+		{name: "empty secret", token: sign(SigningKey{ID: "k4"}, signed)},
+		{name: "wrong secret", token: sign(SigningKey{ID: current.ID, Secret: []byte("other")}, signed)},
+		{name: "expired", token: sign(current, &expired)},
+		{name: "tampered payload", token: base64.RawURLEncoding.EncodeToString([]byte(`{"kid":"k2","id":"2"}`)) + "." + signature},
+		{name: "signature of another token", token: payload + "." + otherSignature},
+		{name: "no signature", token: payload},
+		{name: "empty", token: ""},
+		{name: "not base64", token: "!!!.!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyToken(append([]SigningKey{{ID: "k4"}}, keys...), tt.token)
+
+			if !tt.valid {
+				if status.Code(err) != codes.Unauthenticated {
+					t.Fatalf("got error %v, want Unauthenticated", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Method != signed.Method || got.Subject != signed.Subject || got.ID != signed.ID ||
+				got.Bucket != signed.Bucket || got.Version != signed.Version || got.ExpiresAt.Unix() != signed.ExpiresAt.Unix() {
+				t.Fatalf("got token %+v, want %+v", got, signed)
+			}
+		})
+	}
+}
+
+func TestVerifyToken_Method(t *testing.T) {
+	key := SigningKey{ID: "k1", Secret: []byte("secret")}
+	fsa := NewFileServiceStreamApi(nil, nil, testSigningSettings{key})
+
+	token, err := signToken(key, &SignedToken{
+		Method:    file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fsa.VerifyToken(token, file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fsa.VerifyToken(token, file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("got error %v, want PermissionDenied", err)
+	}
+}
+
+type testSigningSettings []SigningKey
+
+func (testSigningSettings) GetBatchSize() uint32   { return 1 << 16 }
+func (testSigningSettings) GetMaxFileSize() uint32 { return 1 << 20 }
+
+func (ts testSigningSettings) GetSigningKeys() []SigningKey { return ts }
+func (testSigningSettings) GetSignedURLBase() string        { return "" }
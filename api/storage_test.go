@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memStorage keeps files and their versions in memory, the last version is the current one.
+type memStorage struct {
+	mu       sync.Mutex
+	versions map[string][]*memFile
+	sessions map[string]*memSession
+	next     int
+	// query is the last ListFiles query.
+	query *ListQuery
+}
+
+type memFile struct {
+	info *FileInfo
+	data []byte
+}
+
+type memSession struct {
+	meta *UploadMeta
+	data []byte
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		versions: make(map[string][]*memFile),
+		sessions: make(map[string]*memSession),
+	}
+}
+
+func (ms *memStorage) UploadStream(_ context.Context, meta *UploadMeta, file io.Reader) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.next++
+	id := fmt.Sprint(ms.next)
+	ms.versions[id] = []*memFile{newMemFile(id, 1, meta, data)}
+	return id, nil
+}
+
+func (ms *memStorage) DownloadStream(ctx context.Context, id string) (io.ReadCloser, error) {
+	return ms.DownloadVersion(ctx, id, 0)
+}
+
+func (ms *memStorage) DeleteFile(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.versions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(ms.versions, id)
+	return nil
+}
+
+func (ms *memStorage) GetFileInfo(id string) (*FileInfo, error) {
+	return ms.GetVersionInfo(context.Background(), id, 0)
+}
+
+// ListFiles filters by filename prefix and size, page tokens are offsets.
+func (ms *memStorage) ListFiles(query *ListQuery) (*FileInfoList, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.query = query
+
+	var files []*FileInfo
+	for _, versions := range ms.versions {
+		info := versions[len(versions)-1].info
+		if !strings.HasPrefix(info.Filename, query.FilenamePrefix) ||
+			info.Size < query.MinSize ||
+			query.MaxSize > 0 && info.Size > query.MaxSize {
+			continue
+		}
+		files = append(files, copyInfo(info))
+	}
+
+	slices.SortFunc(files, func(a, b *FileInfo) int {
+		var order int
+		switch query.SortBy {
+		case SortByFilename:
+			order = strings.Compare(a.Filename, b.Filename)
+		case SortBySize:
+			order = cmp.Compare(a.Size, b.Size)
+		}
+		// numeric ids keep the upload order
+		order = cmp.Or(order, cmp.Compare(len(a.ID), len(b.ID)), strings.Compare(a.ID, b.ID))
+		if query.Descending {
+			return -order
+		}
+		return order
+	})
+
+	offset := 0
+	if query.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(query.PageToken); err != nil || offset > len(files) {
+			return nil, ErrInvalidArgument
+		}
+	}
+
+	list := &FileInfoList{
+		Total: uint32(len(files)),
+		Files: files[offset:min(offset+int(query.PageSize), len(files))],
+	}
+	if end := offset + int(query.PageSize); end < len(files) {
+		list.NextPageToken = strconv.Itoa(end)
+	}
+	return list, nil
+}
+
+func (ms *memStorage) UploadVersion(_ context.Context, id string, meta *UploadMeta, file io.Reader) (string, uint32, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	versions, ok := ms.versions[id]
+	if !ok {
+		return "", 0, ErrNotFound
+	}
+
+	version := uint32(len(versions) + 1)
+	ms.versions[id] = append(versions, newMemFile(id, version, meta, data))
+	return id, version, nil
+}
+
+func (ms *memStorage) GetVersionInfo(_ context.Context, id string, version uint32) (*FileInfo, error) {
+	file, err := ms.version(id, version)
+	if err != nil {
+		return nil, err
+	}
+	return copyInfo(file.info), nil
+}
+
+func (ms *memStorage) DownloadVersion(_ context.Context, id string, version uint32) (io.ReadCloser, error) {
+	file, err := ms.version(id, version)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(file.data)), nil
+}
+
+// DeleteVersion fails, versions are kept until the file is deleted.
+func (ms *memStorage) DeleteVersion(context.Context, string, uint32) error {
+	return ErrPreconditionFailed
+}
+
+func (ms *memStorage) ListVersions(_ context.Context, id string) ([]*FileInfo, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	versions, ok := ms.versions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	infos := make([]*FileInfo, 0, len(versions))
+	for _, file := range versions {
+		infos = append(infos, copyInfo(file.info))
+	}
+	return infos, nil
+}
+
+// UpdateFileInfo changes the current version only.
+func (ms *memStorage) UpdateFileInfo(_ context.Context, id string, update *FileInfoUpdate) (*FileInfo, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	versions, ok := ms.versions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	info := versions[len(versions)-1].info
+	if update.Filename != nil {
+		info.Filename = *update.Filename
+	}
+	if update.ContentType != nil {
+		info.ContentType = *update.ContentType
+	}
+	if update.Labels != nil {
+		info.Labels = maps.Clone(update.Labels)
+	}
+	if update.Grants != nil {
+		info.Grants = maps.Clone(update.Grants)
+	}
+	return copyInfo(info), nil
+}
+
+func (ms *memStorage) CreateUploadSession(_ context.Context, meta *UploadMeta) (string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.next++
+	id := fmt.Sprintf("session-%d", ms.next)
+	ms.sessions[id] = &memSession{meta: meta}
+	return id, nil
+}
+
+func (ms *memStorage) GetUploadSession(_ context.Context, sessionID string) (*UploadSession, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	session, ok := ms.sessions[sessionID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &UploadSession{
+		ID:        sessionID,
+		Meta:      session.meta,
+		Committed: uint32(len(session.data)),
+	}, nil
+}
+
+// AppendUploadSession keeps the bytes read before a chunk failure.
+func (ms *memStorage) AppendUploadSession(_ context.Context, sessionID string, offset uint32, chunk io.Reader) error {
+	data := &bytes.Buffer{}
+	_, err := io.Copy(data, chunk)
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	session, ok := ms.sessions[sessionID]
+	if !ok {
+		return ErrNotFound
+	}
+	if offset != uint32(len(session.data)) {
+		return ErrPreconditionFailed
+	}
+	session.data = append(session.data, data.Bytes()...)
+	return err
+}
+
+func (ms *memStorage) ReadUploadSession(_ context.Context, sessionID string) (io.ReadCloser, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	session, ok := ms.sessions[sessionID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(session.data)), nil
+}
+
+func (ms *memStorage) CommitUploadSession(_ context.Context, sessionID string, meta *UploadMeta) (string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	session, ok := ms.sessions[sessionID]
+	if !ok {
+		return "", ErrNotFound
+	}
+	delete(ms.sessions, sessionID)
+
+	ms.next++
+	id := fmt.Sprint(ms.next)
+	ms.versions[id] = []*memFile{newMemFile(id, 1, meta, session.data)}
+	return id, nil
+}
+
+// version returns a stored version, version zero is the current one.
+func (ms *memStorage) version(id string, version uint32) (*memFile, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	versions, ok := ms.versions[id]
+	if !ok || int(version) > len(versions) {
+		return nil, ErrNotFound
+	}
+	if version == 0 {
+		version = uint32(len(versions))
+	}
+	return versions[version-1], nil
+}
+
+func newMemFile(id string, version uint32, meta *UploadMeta, data []byte) *memFile {
+	return &memFile{
+		data: data,
+		info: &FileInfo{
+			ID:          id,
+			Size:        uint32(len(data)),
+			Filename:    meta.Filename,
+			ContentType: meta.ContentType,
+			Checksum:    meta.Checksum,
+			CreatedAt:   time.Now(),
+			Labels:      maps.Clone(meta.Labels),
+			Bucket:      meta.Bucket,
+			Version:     version,
+			ExpiresAt:   meta.ExpiresAt,
+			Owner:       meta.Owner,
+			Grants:      maps.Clone(meta.Grants),
+		},
+	}
+}
+
+func copyInfo(info *FileInfo) *FileInfo {
+	copied := *info
+	copied.Labels = maps.Clone(info.Labels)
+	copied.Grants = maps.Clone(info.Grants)
+	return &copied
+}
+
+type testSettings struct{}
+
+func (testSettings) GetBatchSize() uint32   { return 1 << 10 }
+func (testSettings) GetMaxFileSize() uint32 { return 1 << 12 }
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// bodyChunkSize is the size of chunks read from request bodies.
+const bodyChunkSize = 64 * 1024
+
+// uploadStream is the receiving side of an upload stream.
+type uploadStream interface {
+	Context() context.Context
+	Recv() (*file_svc_v1.UploadStreamMsg, error)
+}
+
+// bodyStream exposes a request body as an upload stream of chunks.
+type bodyStream struct {
+	ctx  context.Context
+	body io.Reader
+	buf  []byte
+}
+
+func (bs *bodyStream) Context() context.Context {
+	return bs.ctx
+}
+
+func (bs *bodyStream) Recv() (*file_svc_v1.UploadStreamMsg, error) {
+	num, err := io.ReadFull(bs.body, bs.buf)
+	if num > 0 {
+		return &file_svc_v1.UploadStreamMsg{
+			Data: &file_svc_v1.UploadStreamMsg_Chunk{
+				Chunk: bs.buf[:num],
+			},
+		}, nil
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, io.EOF
+	}
+	return nil, err
+}
+
+// uploadReader exposes the chunks of an upload stream as an io.Reader
+// and collects the metadata of the uploaded file.
+type uploadReader struct {
+	stream      uploadStream
+	maxFileSize uint32
+	batchSize   uint32
+	// unbatched is set for request bodies, which are not chunked by the client.
+	unbatched bool
+	meta      *UploadMeta
+	// partial is set for streams appended to an upload session,
+	// which are not checked as a complete file.
+	partial     bool
+	chunk       []byte
+	eof         bool
+	fileSize    uint32
+	chunksCount int
+	hash        hash.Hash
+	// err keeps the stream failure, so it is not masked by the backend.
+	err error
+}
+
+func newUploadReader(
+	stream file_svc_v1.FileService_UploadStreamServer,
+	settings Settings,
+) *uploadReader {
+	return &uploadReader{
+		stream:      stream,
+		maxFileSize: settings.GetMaxFileSize(),
+		batchSize:   settings.GetBatchSize(),
+		meta:        &UploadMeta{},
+		hash:        sha256.New(),
+	}
+}
+
+// newBodyReader reads the file described by header from body.
+func newBodyReader(ctx context.Context, header *file_svc_v1.UploadHeader, body io.Reader) *uploadReader {
+	return &uploadReader{
+		stream: &bodyStream{
+			ctx:  ctx,
+			body: body,
+			buf:  make([]byte, bodyChunkSize),
+		},
+		unbatched: true,
+		meta:      uploadMeta(header),
+		hash:      sha256.New(),
+	}
+}
+
+// receiveHeader reads the upload header sent as the first message.
+// Legacy clients send the filename as metadata and start with a chunk,
+// which is kept to be read first.
+func (ur *uploadReader) receiveHeader() (*file_svc_v1.UploadHeader, error) {
+
+	req, err := ur.recv()
+	if err != nil && !ur.eof {
+		return nil, err
+	}
+
+	if header := req.GetHeader(); header != nil {
+		ur.meta = uploadMeta(header)
+		return header, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ur.stream.Context())
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "cannot get metadata from context")
+	}
+
+	filenames := md.Get(FilenameHeader)
+	if len(filenames) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "upload header is required")
+	}
+
+	if err := ur.receive(req.GetChunk()); err != nil {
+		return nil, err
+	}
+
+	ur.meta.Filename = filenames[0]
+
+	return &file_svc_v1.UploadHeader{
+		Filename: filenames[0],
+	}, nil
+}
+
+// limit applies the limits of settings to the chunks received next.
+func (ur *uploadReader) limit(settings Settings) {
+	ur.maxFileSize = settings.GetMaxFileSize()
+	if !ur.unbatched {
+		ur.batchSize = settings.GetBatchSize()
+	}
+}
+
+// resume continues the upload session at offset.
+func (ur *uploadReader) resume(session *UploadSession) {
+	ur.meta = session.Meta
+	ur.fileSize = session.Committed
+	ur.partial = true
+}
+
+func (ur *uploadReader) Read(p []byte) (int, error) {
+	if ur.err != nil {
+		return 0, ur.err
+	}
+
+	for len(ur.chunk) == 0 {
+		if ur.eof {
+			return 0, ur.complete()
+		}
+
+		req, err := ur.recv()
+		if err != nil {
+			if ur.eof {
+				continue
+			}
+			ur.err = err
+			return 0, err
+		}
+
+		if req.GetHeader() != nil {
+			ur.err = status.Error(codes.InvalidArgument, "header must be the first message")
+			return 0, ur.err
+		}
+
+		if err := ur.receive(req.GetChunk()); err != nil {
+			ur.err = err
+			return 0, err
+		}
+	}
+
+	num := copy(p, ur.chunk)
+	ur.chunk = ur.chunk[num:]
+	return num, nil
+}
+
+func (ur *uploadReader) recv() (*file_svc_v1.UploadStreamMsg, error) {
+	req, err := ur.stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			ur.eof = true
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "cannot read chunk: %v", err)
+	}
+	return req, nil
+}
+
+// receive checks chunk against the limits before accepting it.
+// Zero limits are not enforced.
+func (ur *uploadReader) receive(chunk []byte) error {
+	size := uint64(len(chunk))
+
+	if ur.batchSize > 0 && size > uint64(ur.batchSize) {
+		return chunkTooLargeError(ur.batchSize)
+	}
+
+	if ur.maxFileSize > 0 && uint64(ur.fileSize)+size > uint64(ur.maxFileSize) {
+		return FileTooLargeError(ur.maxFileSize)
+	}
+
+	if declared := ur.meta.Size; declared > 0 && uint64(ur.fileSize)+size > uint64(declared) {
+		return status.Errorf(codes.InvalidArgument, "file is larger than declared size of %d bytes", declared)
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	ur.hash.Write(chunk)
+	ur.chunk = chunk
+	ur.fileSize += uint32(size)
+	ur.chunksCount++
+	return nil
+}
+
+// complete checks the received file once the client closed the stream.
+// The error is returned before io.EOF, so backends do not keep a broken file.
+func (ur *uploadReader) complete() error {
+	if ur.partial {
+		return io.EOF
+	}
+
+	if declared := ur.meta.Size; declared > 0 && ur.fileSize != declared {
+		ur.err = status.Errorf(codes.InvalidArgument,
+			"received %d bytes, declared size is %d bytes", ur.fileSize, declared)
+		return ur.err
+	}
+
+	checksum := ur.hash.Sum(nil)
+
+	if declared := ur.meta.Checksum; len(declared) > 0 && !bytes.Equal(declared, checksum) {
+		ur.err = checksumMismatchError(declared, checksum)
+		return ur.err
+	}
+
+	ur.meta.Checksum = checksum
+	return io.EOF
+}
+
+func uploadMeta(header *file_svc_v1.UploadHeader) *UploadMeta {
+	return &UploadMeta{
+		Filename:    header.GetFilename(),
+		Size:        header.GetSize(),
+		ContentType: header.GetContentType(),
+		Checksum:    header.GetChecksum(),
+		Labels:      header.GetLabels(),
+		Bucket:      header.GetBucket(),
+		ExpiresAt:   expiresAt(header),
+		Grants:      convertToGrants(header.GetGrants()),
+	}
+}
+
+// chunkSender streams a file to the client in chunks of batchSize bytes.
+type chunkSender struct {
+	stream      file_svc_v1.FileService_DownloadStreamServer
+	batchSize   uint32
+	fileSize    uint32
+	chunksCount int
+	hash        hash.Hash
+}
+
+func newChunkSender(
+	stream file_svc_v1.FileService_DownloadStreamServer,
+	batchSize uint32,
+) *chunkSender {
+	return &chunkSender{
+		stream:    stream,
+		batchSize: batchSize,
+		hash:      sha256.New(),
+	}
+}
+
+func (cs *chunkSender) send(file io.Reader) error {
+
+	buf := make([]byte, cs.batchSize)
+
+	for {
+		num, err := io.ReadFull(file, buf)
+		if num > 0 {
+			if err := cs.stream.Send(&file_svc_v1.DownloadStreamMsg{
+				Chunk: buf[:num],
+			}); err != nil {
+				return err
+			}
+			cs.hash.Write(buf[:num])
+			cs.fileSize += uint32(num)
+			cs.chunksCount++
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+
+		if err != nil {
+			return status.Errorf(codes.Internal, "cannot read file: %v", err)
+		}
+	}
+}
+
+func (cs *chunkSender) checksum() []byte {
+	return cs.hash.Sum(nil)
+}
+
+func checksumMismatchError(expected, actual []byte) error {
+	return status.Errorf(codes.DataLoss, "checksum %s does not match expected %s",
+		hex.EncodeToString(actual), hex.EncodeToString(expected))
+}
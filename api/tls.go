@@ -0,0 +1,44 @@
+package api
+
+import (
+	"crypto/tls"
+
+	"github.com/vishenosik/file-svc-sdk/internal/tlsutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ServerTLSConfig configures the server TLS, each item is read from a file or a PEM string.
+// Certificate files are reloaded when they change, so rotated certificates
+// are used by new connections.
+type ServerTLSConfig struct {
+	// CertFile or CertPEM with KeyFile or KeyPEM are presented to clients.
+	CertFile string
+	CertPEM  string
+	KeyFile  string
+	KeyPEM   string
+	// ClientCAFile or ClientCAPEM enable mTLS, clients must present a certificate signed by the CA.
+	ClientCAFile string
+	ClientCAPEM  string
+}
+
+// NewServerTLSConfig builds the TLS config of config, it also serves HTTPS for the gateway.
+func NewServerTLSConfig(config ServerTLSConfig) (*tls.Config, error) {
+	return tlsutil.ServerConfig(tlsutil.Options{
+		CAFile:   config.ClientCAFile,
+		CAPEM:    config.ClientCAPEM,
+		CertFile: config.CertFile,
+		CertPEM:  config.CertPEM,
+		KeyFile:  config.KeyFile,
+		KeyPEM:   config.KeyPEM,
+	})
+}
+
+// TLSServerOption returns the server option serving TLS with config.
+func TLSServerOption(config ServerTLSConfig) (grpc.ServerOption, error) {
+	tlsConfig, err := NewServerTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Trash is an optional Info extension for soft deletes. DeleteFile moves files
+// to the trash, GetFileInfo and ListFiles must not return trashed files.
+// StreamFileService.DeleteFile removes trashed files permanently.
+type Trash interface {
+	TrashFile(ctx context.Context, id string, deletedAt time.Time) error
+	GetTrashedFile(ctx context.Context, id string) (info *FileInfo, err error)
+	RestoreFile(ctx context.Context, id string) (info *FileInfo, err error)
+	// ListTrash lists trashed files, applying ListQuery.DeletedBefore.
+	ListTrash(ctx context.Context, query *ListQuery) (list *FileInfoList, err error)
+}
+
+// TrashSettings is an optional Settings extension.
+// GetTrashPurgePeriod is how long files stay in the trash, zero keeps them until restored.
+type TrashSettings interface {
+	GetTrashPurgePeriod() time.Duration
+}
+
+func (fsa *FileServiceApi) RestoreFile(ctx context.Context, req *file_svc_v1.FileReq) (*file_svc_v1.FileInfoResp, error) {
+
+	trash, err := fsa.trash()
+	if err != nil {
+		return nil, err
+	}
+
+	trashed, err := trash.GetTrashedFile(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, "cannot get trashed file")
+	}
+
+	if err := inBucket(trashed, req.GetId(), req.GetBucket()); err != nil {
+		return nil, err
+	}
+
+	if err := fsa.authorize(ctx, ActionUpdate, trashed); err != nil {
+		return nil, err
+	}
+
+	info, err := trash.RestoreFile(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, "cannot restore file")
+	}
+
+	return convertToFileInfo(info), nil
+}
+
+func (fsa *FileServiceApi) ListTrash(ctx context.Context, req *file_svc_v1.ListFilesReq) (*file_svc_v1.ListFilesResp, error) {
+
+	trash, err := fsa.trash()
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := convertToListQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// checks the bucket exists
+	if _, err := fsa.bucketSettings(ctx, query.Bucket); err != nil {
+		return nil, err
+	}
+
+	list, err := trash.ListTrash(ctx, query)
+	if err != nil {
+		return nil, statusError(err, "cannot list trash")
+	}
+
+	list, err = fsa.readable(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return convertToFileInfoList(list), nil
+}
+
+func (fsa *FileServiceApi) trash() (Trash, error) {
+	trash, ok := fsa.info.(Trash)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "trash is not supported")
+	}
+	return trash, nil
+}
+
+func (fsa *FileServiceApi) trashPurgePeriod() time.Duration {
+	settings, ok := fsa.settings.(TrashSettings)
+	if !ok {
+		return 0
+	}
+	return settings.GetTrashPurgePeriod()
+}
+
+// TrashReaper permanently deletes files which stayed in the trash
+// longer than TrashSettings.GetTrashPurgePeriod.
+type TrashReaper struct {
+	*periodic
+	api *FileServiceApi
+	log *slog.Logger
+}
+
+// NewTrashReaper creates a reaper checking the trash every interval, which must be positive.
+func NewTrashReaper(fsa *FileServiceApi, interval time.Duration) *TrashReaper {
+	tr := &TrashReaper{
+		api: fsa,
+		log: fsa.log.With(logs.Operation("PurgeTrash")),
+	}
+	tr.periodic = newPeriodic(interval, tr.Purge)
+	return tr
+}
+
+// Start purges the trash until ctx is done or the reaper is stopped.
+func (tr *TrashReaper) Start(ctx context.Context) error {
+	if _, err := tr.api.trash(); err != nil {
+		return err
+	}
+	return tr.periodic.Start(ctx)
+}
+
+// Purge deletes the files trashed before the purge period in every bucket.
+func (tr *TrashReaper) Purge(ctx context.Context) {
+	period := tr.api.trashPurgePeriod()
+	if period <= 0 {
+		return
+	}
+
+	trash, err := tr.api.trash()
+	if err != nil {
+		return
+	}
+
+	names := []string{""}
+	if buckets, ok := tr.api.info.(Buckets); ok {
+		list, err := buckets.ListBuckets(ctx)
+		if err != nil {
+			tr.log.Error("cannot list buckets", logs.Error(err))
+			return
+		}
+		for _, bucket := range list {
+			names = append(names, bucket.Name)
+		}
+	}
+
+	before := time.Now().Add(-period)
+
+	purged := 0
+	for _, bucket := range names {
+		purged += tr.purgeBucket(ctx, trash, bucket, before)
+	}
+
+	if purged > 0 {
+		tr.log.Info("trash purged", slog.Int("purged", purged))
+	}
+}
+
+func (tr *TrashReaper) purgeBucket(ctx context.Context, trash Trash, bucket string, before time.Time) int {
+	query := &ListQuery{
+		PageSize:      maxPageSize,
+		Bucket:        bucket,
+		DeletedBefore: before,
+	}
+
+	purged := 0
+	for {
+		list, err := trash.ListTrash(ctx, query)
+		if err != nil {
+			tr.log.Error("cannot list trash", slog.String("bucket", bucket), logs.Error(err))
+			return purged
+		}
+
+		for _, info := range list.Files {
+			if err := tr.api.svc.DeleteFile(info.ID); err != nil {
+				tr.log.Error("cannot purge file", slog.String("id", info.ID), logs.Error(err))
+				continue
+			}
+			purged++
+		}
+
+		if list.NextPageToken == "" || ctx.Err() != nil {
+			return purged
+		}
+		query.PageToken = list.NextPageToken
+	}
+}
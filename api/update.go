@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"mime"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Update mask paths of UpdateFileInfoReq.
+const (
+	updateFilename    = "filename"
+	updateContentType = "content_type"
+	updateLabels      = "labels"
+	updateGrants      = "grants"
+)
+
+// FileInfoUpdate holds the fields to change, nil fields are left as is.
+type FileInfoUpdate struct {
+	Filename    *string
+	ContentType *string
+	// Labels replace all labels of the file, an empty map removes them.
+	Labels map[string]string
+	// Grants replace all grants of the file, an empty map removes them.
+	Grants map[string]Permission
+}
+
+func (fsa *FileServiceApi) UpdateFileInfo(
+	ctx context.Context,
+	req *file_svc_v1.UpdateFileInfoReq,
+) (*file_svc_v1.FileInfoResp, error) {
+
+	updater, ok := fsa.info.(Updater)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "file info updates are not supported")
+	}
+
+	update, err := convertToFileInfoUpdate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	action := ActionUpdate
+	if update.Grants != nil {
+		action = ActionShare
+	}
+
+	if err := fsa.checkFile(ctx, action, req.GetBucket(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	info, err := updater.UpdateFileInfo(ctx, req.GetId(), update)
+	if err != nil {
+		return nil, statusError(err, "cannot update file info")
+	}
+
+	return convertToFileInfo(info), nil
+}
+
+func convertToFileInfoUpdate(req *file_svc_v1.UpdateFileInfoReq) (*FileInfoUpdate, error) {
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update mask is required")
+	}
+
+	info := req.GetInfo()
+	update := &FileInfoUpdate{}
+
+	for _, path := range paths {
+		switch path {
+		case updateFilename:
+			if err := validateFilename(info.GetFilename()); err != nil {
+				return nil, err
+			}
+			filename := info.GetFilename()
+			update.Filename = &filename
+
+		case updateContentType:
+			contentType := info.GetContentType()
+			if _, _, err := mime.ParseMediaType(contentType); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "content type is not valid: %v", err)
+			}
+			update.ContentType = &contentType
+
+		case updateLabels:
+			if err := validateLabels(info.GetLabels()); err != nil {
+				return nil, err
+			}
+			update.Labels = make(map[string]string, len(info.GetLabels()))
+			for key, value := range info.GetLabels() {
+				update.Labels[key] = value
+			}
+
+		case updateGrants:
+			if err := validateGrants(info.GetGrants()); err != nil {
+				return nil, err
+			}
+			update.Grants = convertToGrants(info.GetGrants())
+			if update.Grants == nil {
+				update.Grants = make(map[string]Permission)
+			}
+
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	return update, nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"crypto/sha256"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxFilenameLength = 255
+)
+
+func validateHeader(header *file_svc_v1.UploadHeader, settings Settings) error {
+
+	if err := validateFilename(header.GetFilename()); err != nil {
+		return err
+	}
+
+	if contentType := header.GetContentType(); contentType != "" {
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return status.Errorf(codes.InvalidArgument, "content type is not valid: %v", err)
+		}
+	}
+
+	if maxFileSize := settings.GetMaxFileSize(); maxFileSize > 0 && header.GetSize() > maxFileSize {
+		return FileTooLargeError(maxFileSize)
+	}
+
+	if checksum := header.GetChecksum(); len(checksum) > 0 && len(checksum) != sha256.Size {
+		return status.Errorf(codes.InvalidArgument, "checksum must be a %d bytes SHA-256 digest", sha256.Size)
+	}
+
+	if err := validateExpiry(header); err != nil {
+		return err
+	}
+
+	if err := validateGrants(header.GetGrants()); err != nil {
+		return err
+	}
+
+	return validateLabels(header.GetLabels())
+}
+
+func validateFilename(filename string) error {
+	switch {
+	case filename == "":
+		return status.Error(codes.InvalidArgument, "filename is required")
+	case len(filename) > maxFilenameLength:
+		return status.Errorf(codes.InvalidArgument, "filename is longer than %d bytes", maxFilenameLength)
+	case !utf8.ValidString(filename):
+		return status.Error(codes.InvalidArgument, "filename is not valid UTF-8")
+	case strings.ContainsAny(filename, "/\\\x00"):
+		return status.Error(codes.InvalidArgument, "filename must not contain path separators")
+	}
+	return nil
+}
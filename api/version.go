@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (fsa *FileServiceApi) ListVersions(ctx context.Context, req *file_svc_v1.FileReq) (*file_svc_v1.ListVersionsResp, error) {
+
+	versioned, err := fsa.versioned()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fsa.checkFile(ctx, ActionRead, req.GetBucket(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	versions, err := versioned.ListVersions(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, "cannot list versions")
+	}
+
+	resp := &file_svc_v1.ListVersionsResp{
+		Versions: make([]*file_svc_v1.FileInfoResp, 0, len(versions)),
+	}
+	for _, info := range versions {
+		resp.Versions = append(resp.Versions, convertToFileInfo(info))
+	}
+
+	return resp, nil
+}
+
+// store stores the file, as a new version when the header asks for it.
+// Uploads of signed URLs were authorized when the URL was created.
+func (fsa *FileServiceApi) store(
+	ctx context.Context,
+	header *file_svc_v1.UploadHeader,
+	meta *UploadMeta,
+	file io.Reader,
+	signed bool,
+) (id string, version uint32, err error) {
+
+	fileID := header.GetFileId()
+
+	if fileID == "" && !header.GetNewVersion() {
+		id, err = fsa.svc.UploadStream(ctx, meta, file)
+		return id, 0, err
+	}
+
+	versioned, err := fsa.versioned()
+	if err != nil {
+		return "", 0, err
+	}
+
+	if fileID == "" {
+		if fsa.authorizer == nil {
+			return versioned.UploadVersion(ctx, fileID, meta, file)
+		}
+
+		// the file is resolved here rather than by the backend, so the authorized file is the one updated
+		named, err := fsa.findFile(meta.Bucket, meta.Filename)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if named == nil {
+			if !signed {
+				if err := fsa.authorize(ctx, ActionCreate, createInfo(meta)); err != nil {
+					return "", 0, err
+				}
+			}
+			return versioned.UploadVersion(ctx, fileID, meta, file)
+		}
+
+		fileID = named.ID
+	}
+
+	info, err := fsa.fileInfo(header.GetBucket(), fileID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	action := ActionUpdate
+	if len(meta.Grants) > 0 {
+		action = ActionShare
+	}
+
+	if !signed {
+		if err := fsa.authorize(ctx, action, info); err != nil {
+			return "", 0, err
+		}
+	}
+
+	// versions keep the owner of the file, and its grants unless they are shared again
+	meta.Owner = info.Owner
+	if len(meta.Grants) == 0 {
+		meta.Grants = info.Grants
+	}
+
+	return versioned.UploadVersion(ctx, fileID, meta, file)
+}
+
+// findFile returns the file named filename in bucket, nil when there is none.
+func (fsa *FileServiceApi) findFile(bucket, filename string) (*FileInfo, error) {
+	query := &ListQuery{
+		PageSize:       maxPageSize,
+		FilenamePrefix: filename,
+		Bucket:         bucket,
+	}
+
+	for {
+		list, err := fsa.info.ListFiles(query)
+		if err != nil {
+			return nil, statusError(err, "cannot list files")
+		}
+
+		for _, info := range list.Files {
+			if info.Filename == filename {
+				return info, nil
+			}
+		}
+
+		if list.NextPageToken == "" {
+			return nil, nil
+		}
+		query.PageToken = list.NextPageToken
+	}
+}
+
+// versionInfo returns the info of a file version stored in bucket, version zero is the current one.
+// The current info is returned as well, access to every version is authorized against it,
+// so owner and grant changes apply to old versions.
+func (fsa *FileServiceApi) versionInfo(ctx context.Context, bucket, id string, version uint32) (info, current *FileInfo, err error) {
+	// versions of an expired file are not served either
+	current, err = fsa.fileInfo(bucket, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version == 0 {
+		return current, current, nil
+	}
+
+	versioned, err := fsa.versioned()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err = versioned.GetVersionInfo(ctx, id, version)
+	if err != nil {
+		return nil, nil, statusError(err, "cannot get file info")
+	}
+
+	if err := inBucket(info, id, bucket); err != nil {
+		return nil, nil, err
+	}
+
+	return info, current, nil
+}
+
+// openFile opens the file contents, version zero is the current one.
+func (fsa *FileServiceApi) openFile(ctx context.Context, id string, version uint32) (io.ReadCloser, error) {
+	if version == 0 {
+		return fsa.svc.DownloadStream(ctx, id)
+	}
+
+	versioned, err := fsa.versioned()
+	if err != nil {
+		return nil, err
+	}
+
+	return versioned.DownloadVersion(ctx, id, version)
+}
+
+func (fsa *FileServiceApi) deleteVersion(ctx context.Context, bucket, id string, version uint32) (*file_svc_v1.DeleteFileResp, error) {
+
+	versioned, err := fsa.versioned()
+	if err != nil {
+		return nil, err
+	}
+
+	_, current, err := fsa.versionInfo(ctx, bucket, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fsa.authorize(ctx, ActionDelete, current); err != nil {
+		return nil, err
+	}
+
+	if err := versioned.DeleteVersion(ctx, id, version); err != nil {
+		return nil, statusError(err, "cannot delete file version")
+	}
+
+	return &file_svc_v1.DeleteFileResp{}, nil
+}
+
+func (fsa *FileServiceApi) versioned() (Versioned, error) {
+	versioned, ok := extension[Versioned](fsa.svc)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "versions are not supported")
+	}
+	return versioned, nil
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+)
+
+// Bucket limits override the server limits, zero limits are inherited.
+type Bucket struct {
+	Name         string    `json:"name"`
+	MaxFileSize  uint32    `json:"max_file_size"`
+	MaxBatchSize uint32    `json:"max_batch_size"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BucketOption func(*file_svc_v1.CreateBucketReq)
+
+func WithMaxFileSize(size uint32) BucketOption {
+	return func(req *file_svc_v1.CreateBucketReq) {
+		req.MaxFileSize = size
+	}
+}
+
+func WithMaxBatchSize(size uint32) BucketOption {
+	return func(req *file_svc_v1.CreateBucketReq) {
+		req.MaxBatchSize = size
+	}
+}
+
+// Bucket returns a handle which scopes all calls to the named bucket.
+// Handles of the same bucket share the cached constraints.
+func (cli *FileServiceClient) Bucket(name string) FileServiceV1 {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	if v1, ok := cli.buckets[name]; ok {
+		return v1
+	}
+
+	v1 := cli.newV1(name)
+	cli.buckets[name] = v1
+	return v1
+}
+
+func (cli *fileServiceV1) CreateBucket(ctx context.Context, name string, opts ...BucketOption) (*Bucket, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	req := &file_svc_v1.CreateBucketReq{
+		Name: name,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := cli.client.CreateBucket(ctx, req)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	bucket := convertBucket(resp)
+	return &bucket, nil
+}
+
+// DeleteBucket deletes an empty bucket.
+func (cli *fileServiceV1) DeleteBucket(ctx context.Context, name string) error {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	_, err := cli.client.DeleteBucket(ctx, &file_svc_v1.BucketReq{
+		Name: name,
+	})
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (cli *fileServiceV1) ListBuckets(ctx context.Context) ([]Bucket, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.ListBuckets(ctx, &file_svc_v1.ListBucketsReq{})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	buckets := make([]Bucket, 0, len(resp.GetBuckets()))
+	for _, bucket := range resp.GetBuckets() {
+		buckets = append(buckets, convertBucket(bucket))
+	}
+
+	return buckets, nil
+}
+
+func convertBucket(bucket *file_svc_v1.Bucket) Bucket {
+	return Bucket{
+		Name:         bucket.GetName(),
+		MaxFileSize:  bucket.GetMaxFileSize(),
+		MaxBatchSize: bucket.GetMaxBatchSize(),
+		CreatedAt:    convertTime(bucket.GetCreatedAt()),
+	}
+}
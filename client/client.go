@@ -3,27 +3,55 @@ package client
 import (
 	"context"
 	"io"
+	"iter"
+	"sync"
 	"time"
 
 	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type FileServiceV1 interface {
 	Download(ctx context.Context, id string) (*DownloadResponse, error)
-	Upload(ctx context.Context, file io.Reader, filename string) (*UploadResponse, error)
-	DeleteFile(id string) error
-	FileInfo(id string) (*FileInfo, error)
-	ListFiles() (*FilesList, error)
+	DownloadRange(ctx context.Context, id string, offset, length uint32) (*DownloadResponse, error)
+	DownloadStream(ctx context.Context, id string) (io.ReadCloser, *FileInfo, error)
+	DownloadTo(ctx context.Context, id string, w io.Writer) (*FileInfo, error)
+	DownloadToFile(ctx context.Context, id string, path string) (*FileInfo, error)
+	Upload(ctx context.Context, file io.Reader, filename string, opts ...UploadOption) (*UploadResponse, error)
+	DeleteFile(ctx context.Context, id string) error
+	DeleteFiles(ctx context.Context, ids []string) ([]DeleteResult, error)
+	FileInfo(ctx context.Context, id string) (*FileInfo, error)
+	UpdateFileLabels(ctx context.Context, id string, labels map[string]string, remove ...string) (*FileInfo, error)
+	UpdateFileInfo(ctx context.Context, id string, opts ...UpdateOption) (*FileInfo, error)
+	CopyFile(ctx context.Context, sourceID, filename string) (*FileInfo, error)
+	Constraints(ctx context.Context) (*Constraints, error)
+	ListFiles(ctx context.Context, opts ...ListOption) (*FilesList, error)
+	AllFiles(ctx context.Context, opts ...ListOption) iter.Seq2[FileInfo, error]
+	CreateBucket(ctx context.Context, name string, opts ...BucketOption) (*Bucket, error)
+	DeleteBucket(ctx context.Context, name string) error
+	ListBuckets(ctx context.Context) ([]Bucket, error)
+	FileVersion(ctx context.Context, id string, version uint32) (*FileInfo, error)
+	DownloadVersion(ctx context.Context, id string, version uint32) (io.ReadCloser, *FileInfo, error)
+	DeleteVersion(ctx context.Context, id string, version uint32) error
+	ListVersions(ctx context.Context, id string) ([]FileInfo, error)
+	RestoreFile(ctx context.Context, id string) (*FileInfo, error)
+	ListTrash(ctx context.Context, opts ...ListOption) (*FilesList, error)
+	SignDownloadURL(ctx context.Context, id string, expiry time.Duration) (*SignedURL, error)
+	SignUploadURL(ctx context.Context, id string, expiry time.Duration) (*SignedURL, error)
+	DownloadSigned(ctx context.Context, token string) (io.ReadCloser, *FileInfo, error)
 }
 
 type FileServiceClient struct {
-	addr    string
-	timeout time.Duration
-	conn    *grpc.ClientConn
-	v1      FileServiceV1
+	addr           string
+	timeout        time.Duration
+	uploadRetries  int
+	constraintsTTL time.Duration
+	conn           *grpc.ClientConn
+	v1             FileServiceV1
+
+	mu      sync.Mutex
+	buckets map[string]FileServiceV1
 }
 
 func NewFileServiceClient(config FileServiceConfig) (*FileServiceClient, error) {
@@ -33,11 +61,19 @@ func NewFileServiceClient(config FileServiceConfig) (*FileServiceClient, error)
 	}
 
 	cli := &FileServiceClient{
-		addr:    config.Addr,
-		timeout: config.Timeout,
+		addr:           config.Addr,
+		timeout:        config.Timeout,
+		uploadRetries:  config.UploadRetries,
+		constraintsTTL: config.ConstraintsTTL,
+		buckets:        make(map[string]FileServiceV1),
+	}
+
+	transport, err := config.transportOption()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := cli.connect(); err != nil {
+	if err := cli.connect(append(config.credentialsOptions(), transport)...); err != nil {
 		return nil, err
 	}
 
@@ -48,14 +84,18 @@ func (cli *FileServiceClient) V1() FileServiceV1 {
 	return cli.v1
 }
 
+// Deprecated: use V1, which takes a context on every method.
+func (cli *FileServiceClient) LegacyV1() LegacyFileServiceV1 {
+	return &legacyFileServiceV1{v1: cli.v1}
+}
+
 func (cli *FileServiceClient) Close(_ context.Context) error {
 	return cli.conn.Close()
 }
 
-func (cli *FileServiceClient) connect() (err error) {
+func (cli *FileServiceClient) connect(opts ...grpc.DialOption) (err error) {
 
-	cli.conn, err = grpc.NewClient(cli.addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	opts = append([]grpc.DialOption{
 		grpc.WithConnectParams(grpc.ConnectParams{
 			Backoff: backoff.Config{
 				BaseDelay:  time.Second,
@@ -65,14 +105,25 @@ func (cli *FileServiceClient) connect() (err error) {
 			},
 			MinConnectTimeout: cli.timeout,
 		}),
-	)
+	}, opts...)
+
+	cli.conn, err = grpc.NewClient(cli.addr, opts...)
 	if err != nil {
 		return err
 	}
 
-	cli.v1 = &fileServiceV1{
-		client: file_svc_v1.NewFileServiceClient(cli.conn),
-	}
+	cli.v1 = cli.newV1("")
+	cli.buckets[""] = cli.v1
 
 	return nil
 }
+
+func (cli *FileServiceClient) newV1(bucket string) *fileServiceV1 {
+	return &fileServiceV1{
+		client:        file_svc_v1.NewFileServiceClient(cli.conn),
+		timeout:       cli.timeout,
+		uploadRetries: cli.uploadRetries,
+		constraints:   newConstraintsCache(cli.constraintsTTL),
+		bucket:        bucket,
+	}
+}
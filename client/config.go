@@ -6,12 +6,29 @@ import (
 )
 
 const (
-	defaultTimeout = time.Second * 15
+	defaultTimeout        = time.Second * 15
+	defaultUploadRetries  = 3
+	defaultConstraintsTTL = time.Minute * 5
 )
 
 type FileServiceConfig struct {
-	Addr    string
+	Addr string
+	// Timeout bounds connecting and is the default deadline of unary calls
+	// made with a context without one. Upload and download streams are
+	// bounded by the caller context only.
 	Timeout time.Duration
+	// UploadRetries limits how many times an upload session is resumed.
+	UploadRetries int
+	// ConstraintsTTL is how long the server constraints are cached.
+	ConstraintsTTL time.Duration
+	// APIKey is sent with every call in the x-api-key header.
+	APIKey string
+	// BearerToken is sent with every call in the authorization header.
+	BearerToken string
+	// TokenSource refreshes the bearer token, it replaces BearerToken.
+	TokenSource TokenSource
+	// TLS secures the connection, it is plaintext without one.
+	TLS *TLSConfig
 }
 
 func (config *FileServiceConfig) validate() error {
@@ -25,5 +42,13 @@ func (config *FileServiceConfig) validate() error {
 		config.Timeout = defaultTimeout
 	}
 
+	if config.UploadRetries <= 0 {
+		config.UploadRetries = defaultUploadRetries
+	}
+
+	if config.ConstraintsTTL <= 0 {
+		config.ConstraintsTTL = defaultConstraintsTTL
+	}
+
 	return nil
 }
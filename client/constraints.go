@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+)
+
+const (
+	defaultBatchSize = 64 * 1024
+)
+
+// Constraints are the server limits. Zero limits are not enforced.
+type Constraints struct {
+	MaxBatchSize uint32
+	MaxFileSize  uint32
+}
+
+func (c *Constraints) batchSize() uint32 {
+	if c.MaxBatchSize == 0 {
+		return defaultBatchSize
+	}
+	return c.MaxBatchSize
+}
+
+// checkSize fails when a file of size bytes cannot be uploaded.
+func (c *Constraints) checkSize(size int64) error {
+	if c.MaxFileSize > 0 && size > int64(c.MaxFileSize) {
+		return convertError(api.FileTooLargeError(c.MaxFileSize))
+	}
+	return nil
+}
+
+// constraintsCache keeps the server constraints for ttl.
+type constraintsCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	constraints Constraints
+	expires     time.Time
+}
+
+func newConstraintsCache(ttl time.Duration) *constraintsCache {
+	return &constraintsCache{ttl: ttl}
+}
+
+func (cc *constraintsCache) get() (*Constraints, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if time.Now().After(cc.expires) {
+		return nil, false
+	}
+
+	constraints := cc.constraints
+	return &constraints, true
+}
+
+func (cc *constraintsCache) set(constraints Constraints) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.constraints = constraints
+	cc.expires = time.Now().Add(cc.ttl)
+}
+
+func (cc *constraintsCache) reset() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.expires = time.Time{}
+}
+
+// Constraints returns the server limits, which are cached for FileServiceConfig.ConstraintsTTL.
+func (cli *fileServiceV1) Constraints(ctx context.Context) (*Constraints, error) {
+	if constraints, ok := cli.constraints.get(); ok {
+		return constraints, nil
+	}
+
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.Constraints(ctx, &file_svc_v1.ConstraintsReq{
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	constraints := Constraints{
+		MaxBatchSize: resp.GetMaxBatchSize(),
+		MaxFileSize:  resp.GetMaxFileSize(),
+	}
+	cli.constraints.set(constraints)
+
+	return &constraints, nil
+}
+
+// sizeOf returns the number of bytes left in file, if it can be known without reading it.
+func sizeOf(file io.Reader) (int64, bool) {
+	switch file := file.(type) {
+	case interface{ Len() int }:
+		return int64(file.Len()), true
+	case io.Seeker:
+		current, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		}
+		if _, err := file.Seek(current, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - current, true
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := file.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		return info.Size(), true
+	}
+	return 0, false
+}
+
+// limitReader fails once more than limit bytes are read.
+type limitReader struct {
+	reader io.Reader
+	limit  uint32
+	read   int64
+}
+
+func (lr *limitReader) Read(p []byte) (int, error) {
+	num, err := lr.reader.Read(p)
+	lr.read += int64(num)
+	if lr.read > int64(lr.limit) {
+		return 0, convertError(api.FileTooLargeError(lr.limit))
+	}
+	return num, err
+}
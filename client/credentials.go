@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	"google.golang.org/grpc"
+)
+
+// tokenRefreshMargin refreshes tokens before they expire in flight.
+const tokenRefreshMargin = time.Second * 30
+
+// TokenSource supplies bearer tokens. Tokens are cached until shortly before
+// their expiry, tokens without expiry are requested for every call.
+type TokenSource interface {
+	Token(ctx context.Context) (token string, expiry time.Time, err error)
+}
+
+// TokenSourceFunc adapts a function to TokenSource.
+type TokenSourceFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+func (fn TokenSourceFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return fn(ctx)
+}
+
+// staticCredentials sends the same metadata with every call.
+type staticCredentials map[string]string
+
+func (sc staticCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return sc, nil
+}
+
+func (staticCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// refreshingCredentials sends bearer tokens of a TokenSource.
+type refreshingCredentials struct {
+	source TokenSource
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (rc *refreshingCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.token == "" || !time.Now().Add(tokenRefreshMargin).Before(rc.expiry) {
+		token, expiry, err := rc.source.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rc.token, rc.expiry = token, expiry
+	}
+
+	return bearerMetadata(rc.token), nil
+}
+
+func (*refreshingCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func bearerMetadata(token string) map[string]string {
+	return map[string]string{
+		api.AuthorizationHeader: "Bearer " + token,
+	}
+}
+
+// credentialsOptions attaches the per-RPC credentials of the config.
+func (config *FileServiceConfig) credentialsOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+
+	if config.APIKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(staticCredentials{api.APIKeyHeader: config.APIKey}))
+	}
+
+	switch {
+	case config.TokenSource != nil:
+		opts = append(opts, grpc.WithPerRPCCredentials(&refreshingCredentials{source: config.TokenSource}))
+	case config.BearerToken != "":
+		opts = append(opts, grpc.WithPerRPCCredentials(staticCredentials(bearerMetadata(config.BearerToken))))
+	}
+
+	return opts
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteResult is the outcome of deleting a single file.
+// Err is nil when the file was deleted and matches ErrNotFound when it did not exist.
+type DeleteResult struct {
+	ID  string
+	Err error
+}
+
+// DeleteFiles deletes ids in a single call. The error is only set when
+// the whole batch failed, per file failures are reported in the results.
+func (cli *fileServiceV1) DeleteFiles(ctx context.Context, ids []string) ([]DeleteResult, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.DeleteFiles(ctx, &file_svc_v1.DeleteFilesReq{
+		Ids:    ids,
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	results := make([]DeleteResult, 0, len(resp.GetResults()))
+	for _, res := range resp.GetResults() {
+		results = append(results, convertDeleteResult(res))
+	}
+
+	return results, nil
+}
+
+func convertDeleteResult(res *file_svc_v1.DeleteFileResult) DeleteResult {
+	result := DeleteResult{
+		ID: res.GetId(),
+	}
+
+	switch res.GetResult() {
+	case file_svc_v1.DeleteResult_DELETE_RESULT_DELETED:
+	case file_svc_v1.DeleteResult_DELETE_RESULT_NOT_FOUND:
+		result.Err = convertError(status.Error(codes.NotFound, res.GetMessage()))
+	default:
+		code := codes.Code(res.GetCode())
+		if code == codes.OK {
+			code = codes.Unknown
+		}
+		result.Err = convertError(status.Error(code, res.GetMessage()))
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/errors"
+)
+
+// downloadReader reads the chunks of a download stream lazily.
+// The checksum sent by the server is verified before io.EOF is returned.
+type downloadReader struct {
+	stream   file_svc_v1.FileService_DownloadStreamClient
+	cancel   context.CancelFunc
+	chunk    []byte
+	fileSize uint32
+	hash     hash.Hash
+	checksum []byte
+	err      error
+}
+
+func (dr *downloadReader) Read(p []byte) (int, error) {
+	if dr.err != nil {
+		return 0, dr.err
+	}
+
+	for len(dr.chunk) == 0 {
+		msg, err := dr.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				dr.err = dr.complete()
+			} else {
+				dr.err = errors.Wrap(convertError(err), "failed to receive message")
+			}
+			return 0, dr.err
+		}
+		dr.receive(msg.GetChunk())
+	}
+
+	num := copy(p, dr.chunk)
+	dr.chunk = dr.chunk[num:]
+	return num, nil
+}
+
+func (dr *downloadReader) receive(chunk []byte) {
+	dr.chunk = chunk
+	dr.fileSize += uint32(len(chunk))
+	dr.hash.Write(chunk)
+}
+
+func (dr *downloadReader) complete() error {
+	dr.checksum = dr.hash.Sum(nil)
+
+	if err := verifyTrailerChecksum(dr.stream.Trailer(), dr.checksum); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// Close cancels the stream if it has not been read to the end.
+func (dr *downloadReader) Close() error {
+	dr.cancel()
+	if dr.err == nil {
+		dr.err = errors.New("download stream is closed")
+	}
+	return nil
+}
+
+// DownloadStream returns the file contents as a reader, which receives
+// chunks from the server as it is read. The reader must be closed.
+func (cli *fileServiceV1) DownloadStream(ctx context.Context, id string) (io.ReadCloser, *FileInfo, error) {
+	return cli.downloadStream(ctx, &file_svc_v1.DownloadReq{
+		Id:     id,
+		Bucket: cli.bucket,
+	})
+}
+
+func (cli *fileServiceV1) downloadStream(
+	ctx context.Context,
+	req *file_svc_v1.DownloadReq,
+) (*downloadReader, *FileInfo, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := cli.client.DownloadStream(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, nil, convertError(err)
+	}
+
+	reader := &downloadReader{
+		stream: stream,
+		cancel: cancel,
+		hash:   sha256.New(),
+	}
+
+	// the first message carries the file info, servers
+	// which do not send it start with a chunk
+	msg, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		cancel()
+		return nil, nil, errors.Wrap(convertError(err), "failed to receive message")
+	}
+
+	info := &FileInfo{ID: req.GetId()}
+	if msg.GetInfo() != nil {
+		*info = convertFileInfo(msg.GetInfo())
+	}
+
+	if errors.Is(err, io.EOF) {
+		reader.err = reader.complete()
+	} else {
+		reader.receive(msg.GetChunk())
+	}
+
+	return reader, info, nil
+}
+
+// DownloadTo writes the file contents to w.
+func (cli *fileServiceV1) DownloadTo(ctx context.Context, id string, w io.Writer) (*FileInfo, error) {
+	file, info, err := cli.DownloadStream(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// DownloadToFile writes the file contents to a temporary file next to path,
+// which is renamed to path once the download succeeds.
+func (cli *fileServiceV1) DownloadToFile(ctx context.Context, id string, path string) (_ *FileInfo, err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary file")
+	}
+
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	info, err := cli.DownloadTo(ctx, id, tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return nil, errors.Wrap(err, "failed to sync temporary file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close temporary file")
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, errors.Wrap(err, "failed to rename temporary file")
+	}
+
+	return info, nil
+}
@@ -1,7 +1,117 @@
 package client
 
-import "github.com/vishenosik/gocherry/pkg/errors"
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 var (
 	ErrInvalidAddr = errors.New("address is not valid")
+
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrQuotaExceeded      = errors.New("quota exceeded")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrOutOfRange         = errors.New("out of range")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+
+	// ErrChecksumMismatch reports transferred bytes which do not match their SHA-256 checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
 )
+
+var codesErrors = map[codes.Code]error{
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.ResourceExhausted:  ErrQuotaExceeded,
+	codes.FailedPrecondition: ErrPreconditionFailed,
+	codes.OutOfRange:         ErrOutOfRange,
+	codes.Unauthenticated:    ErrUnauthenticated,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.DataLoss:           ErrChecksumMismatch,
+}
+
+// statusError matches one of the package errors with errors.Is
+// and keeps the original gRPC status.
+type statusError struct {
+	kind   error
+	status *status.Status
+}
+
+func (se *statusError) Error() string {
+	return se.status.Message()
+}
+
+func (se *statusError) Is(target error) bool {
+	return target == se.kind
+}
+
+func (se *statusError) GRPCStatus() *status.Status {
+	return se.status
+}
+
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	kind, ok := codesErrors[st.Code()]
+	if !ok {
+		return err
+	}
+
+	return &statusError{
+		kind:   kind,
+		status: st,
+	}
+}
+
+func verifyChecksum(expected, actual []byte) error {
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	return errors.Wrapf(ErrChecksumMismatch, "checksum %s does not match expected %s",
+		hex.EncodeToString(actual), hex.EncodeToString(expected))
+}
+
+// Limit returns the server limit exceeded by the request which caused err.
+func Limit(err error) (limit uint32, ok bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != api.ErrorDomain {
+			continue
+		}
+
+		value, ok := info.GetMetadata()[api.LimitMetadataKey]
+		if !ok {
+			continue
+		}
+
+		limit, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return uint32(limit), true
+	}
+
+	return 0, false
+}
@@ -1,199 +1,443 @@
 package client
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
+	"iter"
+	"slices"
+	"time"
 
 	"github.com/vishenosik/file-svc-sdk/api"
 	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
 	"github.com/vishenosik/gocherry/pkg/errors"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type fileServiceV1 struct {
-	client      file_svc_v1.FileServiceClient
-	batchSize   uint32
-	maxFileSize uint32
+	client        file_svc_v1.FileServiceClient
+	timeout       time.Duration
+	uploadRetries int
+	constraints   *constraintsCache
+	// bucket scopes all calls, empty for the default bucket.
+	bucket string
 }
 
 type UploadResponse struct {
-	ID   string
-	Size uint32
+	ID          string
+	Size        uint32
+	ContentType string
+	Checksum    []byte
+	Version     uint32
 }
 
+// Upload uploads file to the server. Files implementing io.ReadSeeker
+// are uploaded through an upload session, which is resumed from the last
+// committed offset when the stream fails with a retryable error.
+//
+// Files of known size larger than the server limit fail before uploading,
+// other files fail once the limit is crossed. New versions of files are
+// uploaded in a single stream.
 func (cli *fileServiceV1) Upload(
 	ctx context.Context,
 	file io.Reader,
 	filename string,
+	opts ...UploadOption,
 ) (*UploadResponse, error) {
 
+	options := newUploadOptions(opts)
+
 	if filename == "" {
 		return nil, errors.New("filename is required")
 	}
 
-	if err := cli.constraints(); err != nil {
+	constraints, err := cli.Constraints(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	md := metadata.New(map[string]string{
-		api.FilenameHeader: filename,
-	})
-	ctx = metadata.NewOutgoingContext(ctx, md)
+	header := options.header(filename, cli.bucket)
+
+	if size, ok := sizeOf(file); ok {
+		if err := constraints.checkSize(size); err != nil {
+			return nil, err
+		}
+		header.Size = uint32(size)
+	} else if constraints.MaxFileSize > 0 {
+		file = &limitReader{
+			reader: file,
+			limit:  constraints.MaxFileSize,
+		}
+	}
+
+	versioned := header.FileId != "" || header.NewVersion
+
+	if seeker, ok := file.(io.ReadSeeker); ok && !versioned {
+		res, err := cli.uploadSession(ctx, seeker, header, constraints)
+		if !errors.Is(err, errSessionsUnsupported) {
+			return res, cli.checkConstraints(err)
+		}
+	}
+
+	hash := sha256.New()
+
+	res, err := cli.uploadStream(ctx, header, io.TeeReader(file, hash), constraints)
+	if err != nil {
+		return nil, cli.checkConstraints(err)
+	}
+
+	if checksum := res.GetChecksum(); len(checksum) > 0 {
+		if err := verifyChecksum(checksum, hash.Sum(nil)); err != nil {
+			return nil, err
+		}
+	}
+
+	return convertUploadResponse(res), nil
+}
+
+// uploadStream sends header followed by the file chunks.
+func (cli *fileServiceV1) uploadStream(
+	ctx context.Context,
+	header *file_svc_v1.UploadHeader,
+	file io.Reader,
+	constraints *Constraints,
+) (*file_svc_v1.UploadStreamResp, error) {
+
+	// cancelling the context aborts the stream when reading file fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	stream, err := cli.client.UploadStream(ctx)
 	if err != nil {
-		return nil, err
+		return nil, convertError(err)
 	}
 
-	buf := make([]byte, cli.batchSize)
-	batchNumber := 1
-	for {
-		num, err := file.Read(buf)
-		if err == io.EOF {
-			break
+	if err := stream.Send(&file_svc_v1.UploadStreamMsg{
+		Data: &file_svc_v1.UploadStreamMsg_Header{
+			Header: header,
+		},
+	}); err != nil {
+		// the server aborted the stream, its status is returned by CloseAndRecv
+		if errors.Is(err, io.EOF) {
+			res, err := stream.CloseAndRecv()
+			return res, convertError(err)
 		}
+		return nil, convertError(err)
+	}
 
-		if err != nil {
-			return nil, err
+	buf := make([]byte, constraints.batchSize())
+	for {
+		num, err := io.ReadFull(file, buf)
+		if num > 0 {
+			if err := stream.Send(&file_svc_v1.UploadStreamMsg{
+				Data: &file_svc_v1.UploadStreamMsg_Chunk{
+					Chunk: buf[:num],
+				},
+			}); err != nil {
+				// the server aborted the stream, its status is returned by CloseAndRecv
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, convertError(err)
+			}
 		}
 
-		chunk := buf[:num]
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
 
-		if err := stream.Send(&file_svc_v1.UploadStreamMsg{
-			Chunk: chunk,
-		}); err != nil {
+		if err != nil {
 			return nil, err
 		}
-		batchNumber += 1
 	}
 
 	res, err := stream.CloseAndRecv()
 	if err != nil {
-		return nil, err
+		return nil, convertError(err)
 	}
 
+	return res, nil
+}
+
+// checkConstraints drops the cached constraints when the server
+// rejected the upload, as its limits might have changed.
+func (cli *fileServiceV1) checkConstraints(err error) error {
+	if errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrInvalidArgument) {
+		cli.constraints.reset()
+	}
+	return err
+}
+
+func convertUploadResponse(res *file_svc_v1.UploadStreamResp) *UploadResponse {
 	return &UploadResponse{
-		ID:   res.GetId(),
-		Size: res.GetSize(),
-	}, nil
+		ID:          res.GetId(),
+		Size:        res.GetSize(),
+		ContentType: res.GetContentType(),
+		Checksum:    res.GetChecksum(),
+		Version:     res.GetVersion(),
+	}
 }
 
 type DownloadResponse struct {
-	ID   string
-	Size uint32
-	File []byte
+	ID       string
+	Size     uint32
+	Checksum []byte
+	File     []byte
 }
 
 func (cli *fileServiceV1) Download(
 	ctx context.Context,
 	id string,
 ) (*DownloadResponse, error) {
+	return cli.DownloadRange(ctx, id, 0, 0)
+}
 
-	stream, err := cli.client.DownloadStream(ctx, &file_svc_v1.FileReq{
-		Id: id,
+// DownloadRange downloads length bytes of the file starting at offset.
+// Zero length downloads the rest of the file.
+func (cli *fileServiceV1) DownloadRange(
+	ctx context.Context,
+	id string,
+	offset, length uint32,
+) (*DownloadResponse, error) {
+
+	file, _, err := cli.downloadStream(ctx, &file_svc_v1.DownloadReq{
+		Id:     id,
+		Offset: offset,
+		Length: length,
+		Bucket: cli.bucket,
 	})
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	imageData := bytes.Buffer{}
-	var (
-		fileSize    uint32
-		chunksCount int
-	)
-
-	for {
-
-		req, err := stream.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return nil, errors.Wrap(err, "failed to receive message")
-		}
-
-		chunk := req.GetChunk()
-		fileSize += uint32(len(chunk))
-
-		if _, err = imageData.Write(chunk); err != nil {
-			return nil, errors.Wrap(err, "failed to write chunk data")
-		}
-
-		chunksCount++
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
 	}
 
 	return &DownloadResponse{
-		ID:   id,
-		Size: fileSize,
-		File: imageData.Bytes(),
+		ID:       id,
+		Size:     file.fileSize,
+		Checksum: file.checksum,
+		File:     data,
 	}, nil
 }
 
-func (cli *fileServiceV1) constraints() error {
-	resp, err := cli.client.Constraints(context.TODO(), &file_svc_v1.ConstraintsReq{})
+// verifyTrailerChecksum compares checksum with the one sent by the server, if any.
+func verifyTrailerChecksum(trailer metadata.MD, checksum []byte) error {
+	values := trailer.Get(api.ChecksumTrailer)
+	if len(values) == 0 {
+		return nil
+	}
+
+	expected, err := hex.DecodeString(values[0])
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to decode checksum trailer")
 	}
-	cli.batchSize = resp.GetMaxBatchSize()
-	cli.maxFileSize = resp.GetMaxFileSize()
-	return nil
+
+	return verifyChecksum(expected, checksum)
 }
 
 type FileInfo struct {
-	ID   string `json:"id"`
-	Size uint32 `json:"size"`
-	Name string `json:"name"`
+	ID          string            `json:"id"`
+	Size        uint32            `json:"size"`
+	Name        string            `json:"name"`
+	ContentType string            `json:"content_type"`
+	Checksum    []byte            `json:"checksum"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Bucket      string            `json:"bucket,omitempty"`
+	Version     uint32            `json:"version,omitempty"`
+	DeletedAt   time.Time         `json:"deleted_at,omitzero"`
+	ExpiresAt   time.Time         `json:"expires_at,omitzero"`
+	// Owner is the subject of the caller which uploaded the file.
+	Owner  string                `json:"owner,omitempty"`
+	Grants map[string]Permission `json:"grants,omitempty"`
 }
 
 type FilesList struct {
-	Total uint32     `json:"total"`
-	Files []FileInfo `json:"files"`
+	Total         uint32     `json:"total"`
+	Files         []FileInfo `json:"files"`
+	NextPageToken string     `json:"next_page_token"`
 }
 
-func (cli *fileServiceV1) ListFiles() (*FilesList, error) {
-	resp, err := cli.client.ListFiles(context.TODO(), &file_svc_v1.ListFilesReq{})
+// ListFiles returns a single page of files.
+func (cli *fileServiceV1) ListFiles(ctx context.Context, opts ...ListOption) (*FilesList, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	req := newListFilesReq(opts)
+	req.Bucket = cli.bucket
+
+	resp, err := cli.client.ListFiles(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, convertError(err)
 	}
 
+	return convertFilesList(resp), nil
+}
+
+func convertFilesList(resp *file_svc_v1.ListFilesResp) *FilesList {
 	files := make([]FileInfo, 0, len(resp.GetFiles()))
 	for _, f := range resp.GetFiles() {
-		files = append(files, FileInfo{
-			ID:   f.GetId(),
-			Size: f.GetSize(),
-			Name: f.GetFilename(),
-		})
+		files = append(files, convertFileInfo(f))
 	}
 
 	return &FilesList{
-		Total: resp.GetTotal(),
-		Files: files,
-	}, nil
+		Total:         resp.GetTotal(),
+		Files:         files,
+		NextPageToken: resp.GetNextPageToken(),
+	}
 }
 
-func (cli *fileServiceV1) FileInfo(id string) (*FileInfo, error) {
-	resp, err := cli.client.GetFileInfo(context.TODO(), &file_svc_v1.FileReq{
-		Id: id,
+// AllFiles walks over all pages of files. Iteration stops after the first error.
+func (cli *fileServiceV1) AllFiles(ctx context.Context, opts ...ListOption) iter.Seq2[FileInfo, error] {
+	return func(yield func(FileInfo, error) bool) {
+		token := ""
+		for {
+			page, err := cli.ListFiles(ctx, append(slices.Clip(opts), WithPageToken(token))...)
+			if err != nil {
+				yield(FileInfo{}, err)
+				return
+			}
+
+			for _, info := range page.Files {
+				if !yield(info, nil) {
+					return
+				}
+			}
+
+			if page.NextPageToken == "" {
+				return
+			}
+			token = page.NextPageToken
+		}
+	}
+}
+
+func (cli *fileServiceV1) FileInfo(ctx context.Context, id string) (*FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.GetFileInfo(ctx, &file_svc_v1.FileReq{
+		Id:     id,
+		Bucket: cli.bucket,
 	})
 	if err != nil {
-		return nil, err
+		return nil, convertError(err)
 	}
 
-	return &FileInfo{
-		ID:   resp.GetId(),
-		Size: resp.GetSize(),
-		Name: resp.GetFilename(),
-	}, nil
+	info := convertFileInfo(resp)
+	return &info, nil
+}
+
+// UpdateFileLabels sets labels on the file and removes labels with the remove keys.
+func (cli *fileServiceV1) UpdateFileLabels(
+	ctx context.Context,
+	id string,
+	labels map[string]string,
+	remove ...string,
+) (*FileInfo, error) {
+
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.UpdateFileLabels(ctx, &file_svc_v1.UpdateFileLabelsReq{
+		Id:     id,
+		Labels: labels,
+		Remove: remove,
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	info := convertFileInfo(resp)
+	return &info, nil
 }
 
-func (cli *fileServiceV1) DeleteFile(id string) error {
-	_, err := cli.client.DeleteFile(context.TODO(), &file_svc_v1.FileReq{
-		Id: id,
+// UpdateFileInfo changes the file info fields set by opts.
+func (cli *fileServiceV1) UpdateFileInfo(ctx context.Context, id string, opts ...UpdateOption) (*FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	req := newUpdateFileInfoReq(id, opts)
+	req.Bucket = cli.bucket
+
+	resp, err := cli.client.UpdateFileInfo(ctx, req)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	info := convertFileInfo(resp)
+	return &info, nil
+}
+
+// CopyFile copies the file on the server. Empty filename keeps the source filename.
+func (cli *fileServiceV1) CopyFile(ctx context.Context, sourceID, filename string) (*FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.CopyFile(ctx, &file_svc_v1.CopyFileReq{
+		SourceId: sourceID,
+		Filename: filename,
+		Bucket:   cli.bucket,
 	})
 	if err != nil {
-		return err
+		return nil, convertError(err)
+	}
+
+	info := convertFileInfo(resp)
+	return &info, nil
+}
+
+func convertFileInfo(info *file_svc_v1.FileInfoResp) FileInfo {
+	return FileInfo{
+		ID:          info.GetId(),
+		Size:        info.GetSize(),
+		Name:        info.GetFilename(),
+		ContentType: info.GetContentType(),
+		Checksum:    info.GetChecksum(),
+		CreatedAt:   convertTime(info.GetCreatedAt()),
+		Labels:      info.GetLabels(),
+		Bucket:      info.GetBucket(),
+		Version:     info.GetVersion(),
+		DeletedAt:   convertTime(info.GetDeletedAt()),
+		ExpiresAt:   convertTime(info.GetExpiresAt()),
+		Owner:       info.GetOwner(),
+		Grants:      convertGrants(info.GetGrants()),
+	}
+}
+
+func convertTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func (cli *fileServiceV1) DeleteFile(ctx context.Context, id string) error {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	_, err := cli.client.DeleteFile(ctx, &file_svc_v1.FileReq{
+		Id:     id,
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return convertError(err)
 	}
 
 	return nil
 }
+
+// withTimeout applies the configured timeout to calls without a deadline.
+func (cli *fileServiceV1) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, cli.timeout)
+}
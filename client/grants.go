@@ -0,0 +1,39 @@
+package client
+
+import (
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+)
+
+// EveryoneGrant is the grants key applying to all callers.
+const EveryoneGrant = "*"
+
+// Permission is granted to callers other than the file owner.
+type Permission int32
+
+const (
+	PermissionRead Permission = iota + 1
+	// PermissionWrite allows to read, update and delete the file.
+	PermissionWrite
+)
+
+func convertToGrants(grants map[string]Permission) map[string]file_svc_v1.Permission {
+	if grants == nil {
+		return nil
+	}
+	converted := make(map[string]file_svc_v1.Permission, len(grants))
+	for subject, permission := range grants {
+		converted[subject] = file_svc_v1.Permission(permission)
+	}
+	return converted
+}
+
+func convertGrants(grants map[string]file_svc_v1.Permission) map[string]Permission {
+	if len(grants) == 0 {
+		return nil
+	}
+	converted := make(map[string]Permission, len(grants))
+	for subject, permission := range grants {
+		converted[subject] = Permission(permission)
+	}
+	return converted
+}
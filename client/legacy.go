@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"io"
+)
+
+// LegacyFileServiceV1 keeps the FileServiceV1 signatures without context.
+//
+// Deprecated: use FileServiceV1.
+type LegacyFileServiceV1 interface {
+	Download(ctx context.Context, id string) (*DownloadResponse, error)
+	Upload(ctx context.Context, file io.Reader, filename string, opts ...UploadOption) (*UploadResponse, error)
+	DeleteFile(id string) error
+	FileInfo(id string) (*FileInfo, error)
+	ListFiles(opts ...ListOption) (*FilesList, error)
+}
+
+type legacyFileServiceV1 struct {
+	v1 FileServiceV1
+}
+
+func (cli *legacyFileServiceV1) Download(ctx context.Context, id string) (*DownloadResponse, error) {
+	return cli.v1.Download(ctx, id)
+}
+
+func (cli *legacyFileServiceV1) Upload(
+	ctx context.Context,
+	file io.Reader,
+	filename string,
+	opts ...UploadOption,
+) (*UploadResponse, error) {
+	return cli.v1.Upload(ctx, file, filename, opts...)
+}
+
+func (cli *legacyFileServiceV1) DeleteFile(id string) error {
+	return cli.v1.DeleteFile(context.Background(), id)
+}
+
+func (cli *legacyFileServiceV1) FileInfo(id string) (*FileInfo, error) {
+	return cli.v1.FileInfo(context.Background(), id)
+}
+
+// ListFiles returns all files, as it did before the server paged the list.
+func (cli *legacyFileServiceV1) ListFiles(opts ...ListOption) (*FilesList, error) {
+	list := &FilesList{}
+
+	for info, err := range cli.v1.AllFiles(context.Background(), opts...) {
+		if err != nil {
+			return nil, err
+		}
+		list.Files = append(list.Files, info)
+	}
+
+	list.Total = uint32(len(list.Files))
+	return list, nil
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type uploadOptions struct {
+	contentType string
+	checksum    []byte
+	labels      map[string]string
+	grants      map[string]Permission
+	fileID      string
+	newVersion  bool
+	expiresAt   time.Time
+	ttl         time.Duration
+}
+
+type UploadOption func(*uploadOptions)
+
+// WithContentType declares the content type of the uploaded file.
+// The server detects it from the file contents otherwise.
+func WithContentType(contentType string) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.contentType = contentType
+	}
+}
+
+// WithChecksum declares the SHA-256 checksum of the uploaded file.
+// The server rejects the upload if the received bytes do not match it.
+func WithChecksum(checksum []byte) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.checksum = checksum
+	}
+}
+
+// WithLabels attaches key/value labels to the uploaded file.
+func WithLabels(labels map[string]string) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.labels = labels
+	}
+}
+
+// WithGrants gives subjects other than the owner access to the uploaded file.
+// Versions keep the grants of the file without it.
+func WithGrants(grants map[string]Permission) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.grants = grants
+	}
+}
+
+// WithVersionOf uploads the file as a new version of the file id.
+func WithVersionOf(id string) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.fileID = id
+	}
+}
+
+// WithNewVersion uploads the file as a new version of the file with the same name,
+// which is created when it does not exist.
+func WithNewVersion() UploadOption {
+	return func(opts *uploadOptions) {
+		opts.newVersion = true
+	}
+}
+
+// WithExpiresAt makes the server delete the file at expiresAt.
+func WithExpiresAt(expiresAt time.Time) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.expiresAt = expiresAt
+	}
+}
+
+// WithTTL makes the server delete the file once ttl has passed since the upload.
+func WithTTL(ttl time.Duration) UploadOption {
+	return func(opts *uploadOptions) {
+		opts.ttl = ttl
+	}
+}
+
+func newUploadOptions(opts []UploadOption) *uploadOptions {
+	options := &uploadOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// header builds the upload header of the file in bucket.
+func (opts *uploadOptions) header(filename, bucket string) *file_svc_v1.UploadHeader {
+	header := &file_svc_v1.UploadHeader{
+		Filename:    filename,
+		ContentType: opts.contentType,
+		Checksum:    opts.checksum,
+		Labels:      opts.labels,
+		Grants:      convertToGrants(opts.grants),
+		Bucket:      bucket,
+		FileId:      opts.fileID,
+		NewVersion:  opts.newVersion,
+	}
+
+	if !opts.expiresAt.IsZero() {
+		header.ExpiresAt = timestamppb.New(opts.expiresAt)
+	}
+
+	if opts.ttl != 0 {
+		header.Ttl = durationpb.New(opts.ttl)
+	}
+
+	return header
+}
+
+type SortField int32
+
+const (
+	SortByDefault SortField = iota
+	SortByFilename
+	SortBySize
+	SortByCreatedAt
+)
+
+type ListOption func(*file_svc_v1.ListFilesReq)
+
+func WithPageSize(size uint32) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.PageSize = size
+	}
+}
+
+// WithPageToken continues listing from FilesList.NextPageToken.
+func WithPageToken(token string) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.PageToken = token
+	}
+}
+
+func WithFilenamePrefix(prefix string) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.FilenamePrefix = prefix
+	}
+}
+
+// WithSizeRange lists files with size within [min, max], zero bounds are not applied.
+func WithSizeRange(min, max uint32) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.MinSize = min
+		req.MaxSize = max
+	}
+}
+
+// WithCreatedRange lists files created within [after, before], zero bounds are not applied.
+func WithCreatedRange(after, before time.Time) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		if !after.IsZero() {
+			req.CreatedAfter = timestamppb.New(after)
+		}
+		if !before.IsZero() {
+			req.CreatedBefore = timestamppb.New(before)
+		}
+	}
+}
+
+// WithLabelSelector lists files matching selector,
+// e.g. "tenant=acme,kind in (invoice,receipt),!draft".
+func WithLabelSelector(selector string) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.LabelSelector = selector
+	}
+}
+
+func WithSort(field SortField, descending bool) ListOption {
+	return func(req *file_svc_v1.ListFilesReq) {
+		req.SortBy = file_svc_v1.SortField(field)
+		req.Descending = descending
+	}
+}
+
+func newListFilesReq(opts []ListOption) *file_svc_v1.ListFilesReq {
+	req := &file_svc_v1.ListFilesReq{}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+type UpdateOption func(*file_svc_v1.UpdateFileInfoReq)
+
+func Rename(filename string) UpdateOption {
+	return func(req *file_svc_v1.UpdateFileInfoReq) {
+		req.Info.Filename = filename
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "filename")
+	}
+}
+
+func SetContentType(contentType string) UpdateOption {
+	return func(req *file_svc_v1.UpdateFileInfoReq) {
+		req.Info.ContentType = contentType
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "content_type")
+	}
+}
+
+// SetLabels replaces all labels of the file, an empty map removes them.
+func SetLabels(labels map[string]string) UpdateOption {
+	return func(req *file_svc_v1.UpdateFileInfoReq) {
+		req.Info.Labels = labels
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "labels")
+	}
+}
+
+// SetGrants replaces all grants of the file, an empty map removes them.
+// Only the owner of the file may change its grants.
+func SetGrants(grants map[string]Permission) UpdateOption {
+	return func(req *file_svc_v1.UpdateFileInfoReq) {
+		req.Info.Grants = convertToGrants(grants)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "grants")
+	}
+}
+
+func newUpdateFileInfoReq(id string, opts []UpdateOption) *file_svc_v1.UpdateFileInfoReq {
+	req := &file_svc_v1.UpdateFileInfoReq{
+		Id:         id,
+		Info:       &file_svc_v1.FileInfoResp{},
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	retryBaseDelay = time.Second / 2
+)
+
+var errSessionsUnsupported = errors.New("upload sessions are not supported")
+
+// uploadSession uploads file through a resumable upload session.
+// The size and checksum of file are declared upfront, so the server
+// verifies the assembled file when the session is committed.
+func (cli *fileServiceV1) uploadSession(
+	ctx context.Context,
+	file io.ReadSeeker,
+	header *file_svc_v1.UploadHeader,
+	constraints *Constraints,
+) (*UploadResponse, error) {
+
+	start, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, errSessionsUnsupported
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	header.Size = uint32(size)
+	if len(header.Checksum) == 0 {
+		header.Checksum = hash.Sum(nil)
+	}
+
+	session, err := cli.createUploadSession(ctx, header)
+	if status.Code(err) == codes.Unimplemented {
+		if _, err := file.Seek(start, io.SeekStart); err != nil {
+			return nil, errors.Wrap(err, "failed to seek file")
+		}
+		return nil, errSessionsUnsupported
+	}
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	sessionID := session.GetSessionId()
+
+	var offset uint32
+	for attempt := 0; ; attempt++ {
+
+		if attempt > 0 {
+			if err := sleep(ctx, retryBaseDelay<<(attempt-1)); err != nil {
+				return nil, err
+			}
+
+			session, err := cli.getUploadSession(ctx, sessionID)
+			if err != nil {
+				if attempt < cli.uploadRetries && retryable(ctx, err) {
+					continue
+				}
+				return nil, convertError(err)
+			}
+			offset = session.GetCommitted()
+		}
+
+		if _, err := file.Seek(start+int64(offset), io.SeekStart); err != nil {
+			return nil, errors.Wrap(err, "failed to seek file")
+		}
+
+		_, err := cli.uploadStream(ctx, &file_svc_v1.UploadHeader{
+			SessionId: sessionID,
+			Offset:    offset,
+		}, file, constraints)
+		if err == nil {
+			break
+		}
+
+		if attempt >= cli.uploadRetries || !retryable(ctx, err) {
+			return nil, err
+		}
+	}
+
+	res, err := cli.client.CommitUpload(ctx, &file_svc_v1.UploadSessionReq{
+		SessionId: sessionID,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	if err := verifyChecksum(res.GetChecksum(), header.Checksum); err != nil {
+		return nil, err
+	}
+
+	return convertUploadResponse(res), nil
+}
+
+func (cli *fileServiceV1) createUploadSession(
+	ctx context.Context,
+	header *file_svc_v1.UploadHeader,
+) (*file_svc_v1.UploadSession, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	return cli.client.CreateUploadSession(ctx, &file_svc_v1.CreateUploadSessionReq{
+		Header: header,
+	})
+}
+
+func (cli *fileServiceV1) getUploadSession(ctx context.Context, sessionID string) (*file_svc_v1.UploadSession, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	return cli.client.GetUploadSession(ctx, &file_svc_v1.UploadSessionReq{
+		SessionId: sessionID,
+	})
+}
+
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// SignedURL grants access to a single file without service credentials.
+// URL points to the HTTP gateway, Token can also be passed to DownloadSigned.
+type SignedURL struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SignDownloadURL creates a URL downloading the file until expiry passes,
+// zero expiry uses the server default.
+func (cli *fileServiceV1) SignDownloadURL(ctx context.Context, id string, expiry time.Duration) (*SignedURL, error) {
+	return cli.createSignedURL(ctx, id, file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD, expiry)
+}
+
+// SignUploadURL creates a URL uploading a new file, or a new version of the file
+// when id is set, until expiry passes. Zero expiry uses the server default.
+func (cli *fileServiceV1) SignUploadURL(ctx context.Context, id string, expiry time.Duration) (*SignedURL, error) {
+	return cli.createSignedURL(ctx, id, file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD, expiry)
+}
+
+func (cli *fileServiceV1) createSignedURL(
+	ctx context.Context,
+	id string,
+	method file_svc_v1.SignedMethod,
+	expiry time.Duration,
+) (*SignedURL, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	req := &file_svc_v1.CreateSignedURLReq{
+		Id:     id,
+		Method: method,
+		Bucket: cli.bucket,
+	}
+	if expiry != 0 {
+		req.ExpiresIn = durationpb.New(expiry)
+	}
+
+	resp, err := cli.client.CreateSignedURL(ctx, req)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return &SignedURL{
+		Token:     resp.GetToken(),
+		URL:       resp.GetUrl(),
+		ExpiresAt: convertTime(resp.GetExpiresAt()),
+	}, nil
+}
+
+// DownloadSigned works as DownloadStream for the file of a signed download token.
+func (cli *fileServiceV1) DownloadSigned(ctx context.Context, token string) (io.ReadCloser, *FileInfo, error) {
+	return cli.downloadStream(ctx, &file_svc_v1.DownloadReq{
+		Token: token,
+	})
+}
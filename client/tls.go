@@ -0,0 +1,49 @@
+package client
+
+import (
+	"github.com/vishenosik/file-svc-sdk/internal/tlsutil"
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig enables TLS, each item is read from a file or a PEM string.
+// Certificate files are reloaded when they change, so rotated certificates
+// are used by new connections.
+type TLSConfig struct {
+	// CAFile or CAPEM verify the server, the system roots are used without them.
+	CAFile string
+	CAPEM  string
+	// CertFile or CertPEM with KeyFile or KeyPEM authenticate the client for mTLS.
+	CertFile string
+	CertPEM  string
+	KeyFile  string
+	KeyPEM   string
+	// ServerName overrides the name verified in the server certificate.
+	ServerName string
+	// InsecureSkipVerify disables server verification, use it for testing only.
+	InsecureSkipVerify bool
+}
+
+// transportOption secures the connection with TLS when the config has it.
+func (config *FileServiceConfig) transportOption() (grpc.DialOption, error) {
+	if config.TLS == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+
+	creds, err := tlsutil.ClientCredentials(tlsutil.Options{
+		CAFile:             config.TLS.CAFile,
+		CAPEM:              config.TLS.CAPEM,
+		CertFile:           config.TLS.CertFile,
+		CertPEM:            config.TLS.CertPEM,
+		KeyFile:            config.TLS.KeyFile,
+		KeyPEM:             config.TLS.KeyPEM,
+		ServerName:         config.TLS.ServerName,
+		InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load TLS config")
+	}
+
+	return grpc.WithTransportCredentials(creds), nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+)
+
+// RestoreFile moves a deleted file out of the trash.
+func (cli *fileServiceV1) RestoreFile(ctx context.Context, id string) (*FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.RestoreFile(ctx, &file_svc_v1.FileReq{
+		Id:     id,
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	info := convertFileInfo(resp)
+	return &info, nil
+}
+
+// ListTrash returns a single page of deleted files.
+func (cli *fileServiceV1) ListTrash(ctx context.Context, opts ...ListOption) (*FilesList, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	req := newListFilesReq(opts)
+	req.Bucket = cli.bucket
+
+	resp, err := cli.client.ListTrash(ctx, req)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return convertFilesList(resp), nil
+}
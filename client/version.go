@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+)
+
+func (cli *fileServiceV1) FileVersion(ctx context.Context, id string, version uint32) (*FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.GetFileInfo(ctx, &file_svc_v1.FileReq{
+		Id:      id,
+		Bucket:  cli.bucket,
+		Version: version,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	info := convertFileInfo(resp)
+	return &info, nil
+}
+
+// DownloadVersion works as DownloadStream for a version of the file.
+func (cli *fileServiceV1) DownloadVersion(ctx context.Context, id string, version uint32) (io.ReadCloser, *FileInfo, error) {
+	return cli.downloadStream(ctx, &file_svc_v1.DownloadReq{
+		Id:      id,
+		Bucket:  cli.bucket,
+		Version: version,
+	})
+}
+
+// DeleteVersion deletes a single version of the file.
+func (cli *fileServiceV1) DeleteVersion(ctx context.Context, id string, version uint32) error {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	_, err := cli.client.DeleteFile(ctx, &file_svc_v1.FileReq{
+		Id:      id,
+		Bucket:  cli.bucket,
+		Version: version,
+	})
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (cli *fileServiceV1) ListVersions(ctx context.Context, id string) ([]FileInfo, error) {
+	ctx, cancel := cli.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := cli.client.ListVersions(ctx, &file_svc_v1.FileReq{
+		Id:     id,
+		Bucket: cli.bucket,
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	versions := make([]FileInfo, 0, len(resp.GetVersions()))
+	for _, info := range resp.GetVersions() {
+		versions = append(versions, convertFileInfo(info))
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,326 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// multipartField is the form field holding the file of multipart uploads.
+const multipartField = "file"
+
+// upload stores the request body. Raw bodies take the filename from the query,
+// multipart bodies from the file part unless the query overrides it.
+//
+// Query parameters: filename, bucket, checksum (hex SHA-256), label (key=value, repeated),
+// token (signed upload URL, replaces bucket).
+func (h *Handler) upload(w http.ResponseWriter, r *http.Request) {
+
+	header, err := uploadHeader(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	body := io.Reader(r.Body)
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, err := filePart(r)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		defer part.Close()
+
+		if header.Filename == "" {
+			header.Filename = part.FileName()
+		}
+		header.ContentType = declaredContentType(part.Header.Get("Content-Type"))
+		body = part
+	} else {
+		header.ContentType = declaredContentType(r.Header.Get("Content-Type"))
+		if r.ContentLength > 0 {
+			header.Size = uint32(min(r.ContentLength, int64(^uint32(0))))
+		}
+	}
+
+	resp, err := h.api.Upload(r.Context(), header, body)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/files/"+resp.GetId())
+	h.writeJSON(w, http.StatusCreated, resp)
+}
+
+// declaredContentType drops the content types set by HTTP clients for any body,
+// so the type of those uploads is sniffed from the contents.
+func declaredContentType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/x-www-form-urlencoded" || mediaType == "application/octet-stream") {
+		return ""
+	}
+	return contentType
+}
+
+func uploadHeader(r *http.Request) (*file_svc_v1.UploadHeader, error) {
+	query := r.URL.Query()
+
+	header := &file_svc_v1.UploadHeader{
+		Filename: query.Get("filename"),
+		Bucket:   query.Get("bucket"),
+		Token:    query.Get("token"),
+	}
+
+	if checksum := query.Get("checksum"); checksum != "" {
+		decoded, err := hex.DecodeString(checksum)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "checksum is not valid hex: %v", err)
+		}
+		header.Checksum = decoded
+	}
+
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "label %q must be key=value", label)
+		}
+		if header.Labels == nil {
+			header.Labels = make(map[string]string)
+		}
+		header.Labels[key] = value
+	}
+
+	return header, nil
+}
+
+// filePart returns the file part of a multipart body, skipping other fields.
+func filePart(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot read multipart body: %v", err)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, status.Errorf(codes.InvalidArgument, "multipart body has no %q field", multipartField)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot read multipart body: %v", err)
+		}
+		if part.FormName() == multipartField {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// download serves the file with conditional and range requests support.
+//
+// Query parameters: bucket, version, token (signed download URL, replaces bucket and version).
+func (h *Handler) download(w http.ResponseWriter, r *http.Request) {
+
+	file, err := fileReq(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	req := &file_svc_v1.DownloadReq{
+		Id:      file.GetId(),
+		Bucket:  file.GetBucket(),
+		Version: file.GetVersion(),
+		Token:   r.URL.Query().Get("token"),
+	}
+
+	info, err := h.api.Stat(r.Context(), req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if req.GetToken() == "" {
+		// pins the version, so ranges are read from the same contents
+		req.Version = info.Version
+	}
+
+	content := &fileSeeker{
+		api:  h.api,
+		req:  req,
+		ctx:  r.Context(),
+		size: int64(info.Size),
+	}
+	defer content.Close()
+
+	if len(info.Checksum) > 0 {
+		w.Header().Set("ETag", strconv.Quote(hex.EncodeToString(info.Checksum)))
+	}
+	if info.ContentType != "" {
+		w.Header().Set("Content-Type", info.ContentType)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": info.Filename,
+	}))
+
+	http.ServeContent(w, r, info.Filename, info.CreatedAt, content)
+
+	if content.err != nil {
+		h.log.Error("download failed", logs.Error(content.err))
+	}
+}
+
+func fileReq(r *http.Request) (*file_svc_v1.FileReq, error) {
+	query := r.URL.Query()
+
+	req := &file_svc_v1.FileReq{
+		Id:     r.PathValue("id"),
+		Bucket: query.Get("bucket"),
+	}
+
+	if version := query.Get("version"); version != "" {
+		parsed, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "version is not valid: %v", err)
+		}
+		req.Version = uint32(parsed)
+	}
+
+	return req, nil
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+
+	req, err := fileReq(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if _, err := h.api.DeleteFile(r.Context(), req); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// list replies with a page of files.
+//
+// Query parameters: page_size, page_token, prefix, label_selector, bucket.
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req := &file_svc_v1.ListFilesReq{
+		PageToken:      query.Get("page_token"),
+		FilenamePrefix: query.Get("prefix"),
+		LabelSelector:  query.Get("label_selector"),
+		Bucket:         query.Get("bucket"),
+	}
+
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		parsed, err := strconv.ParseUint(pageSize, 10, 32)
+		if err != nil {
+			h.writeError(w, status.Errorf(codes.InvalidArgument, "page size is not valid: %v", err))
+			return
+		}
+		req.PageSize = uint32(parsed)
+	}
+
+	resp, err := h.api.ListFiles(r.Context(), req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// fileSeeker adapts downloads to io.ReadSeeker for http.ServeContent,
+// opening the file lazily at the current offset.
+type fileSeeker struct {
+	api    *api.FileServiceApi
+	req    *file_svc_v1.DownloadReq
+	ctx    context.Context
+	size   int64
+	offset int64
+	file   io.ReadCloser
+	err    error
+}
+
+func (fs *fileSeeker) Read(p []byte) (int, error) {
+	if fs.offset >= fs.size {
+		return 0, io.EOF
+	}
+
+	if fs.file == nil {
+		req := fs.req
+		if fs.offset > 0 {
+			req = &file_svc_v1.DownloadReq{
+				Id:      fs.req.GetId(),
+				Bucket:  fs.req.GetBucket(),
+				Version: fs.req.GetVersion(),
+				Token:   fs.req.GetToken(),
+				Offset:  uint32(fs.offset),
+				Length:  uint32(fs.size - fs.offset),
+			}
+		}
+
+		file, _, err := fs.api.Open(fs.ctx, req)
+		if err != nil {
+			fs.err = err
+			return 0, err
+		}
+		fs.file = file
+	}
+
+	num, err := fs.file.Read(p)
+	fs.offset += int64(num)
+	if err != nil && err != io.EOF {
+		fs.err = err
+	}
+	return num, err
+}
+
+func (fs *fileSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += fs.offset
+	case io.SeekEnd:
+		offset += fs.size
+	}
+
+	if offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "negative offset")
+	}
+
+	if offset != fs.offset {
+		fs.Close()
+		fs.offset = offset
+	}
+	return offset, nil
+}
+
+func (fs *fileSeeker) Close() error {
+	if fs.file == nil {
+		return nil
+	}
+	err := fs.file.Close()
+	fs.file = nil
+	return err
+}
@@ -0,0 +1,171 @@
+// Package gateway exposes FileServiceApi over plain HTTP for browsers and scripts
+// which cannot use gRPC streams.
+package gateway
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"github.com/vishenosik/gocherry/pkg/logs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Handler serves the files API:
+//
+//	POST   /files       uploads a raw or multipart/form-data body
+//	GET    /files       lists files as JSON
+//	GET    /files/{id}  downloads a file, HEAD and Range are supported
+//	DELETE /files/{id}  deletes a file
+type Handler struct {
+	api  *api.FileServiceApi
+	auth api.Authenticator
+	mux  *http.ServeMux
+	log  *slog.Logger
+}
+
+type Option func(h *Handler)
+
+// Routes which accept a signed URL token instead of credentials.
+const (
+	uploadPattern   = "POST /files"
+	downloadPattern = "GET /files/{id}"
+)
+
+// WithAuthenticator authenticates requests by their headers, as the gRPC interceptors do.
+// Requests with a signed URL token are authorized by the token instead.
+func WithAuthenticator(auth api.Authenticator) Option {
+	return func(h *Handler) {
+		h.auth = auth
+	}
+}
+
+// NewHandler creates the HTTP gateway on top of fsa, sharing its validation and limits.
+func NewHandler(fsa *api.FileServiceApi, opts ...Option) *Handler {
+	h := &Handler{
+		api: fsa,
+		mux: http.NewServeMux(),
+		log: logs.SetupLogger().With(logs.AppComponent("HTTP")),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc(uploadPattern, h.upload)
+	h.mux.HandleFunc("GET /files", h.list)
+	h.mux.HandleFunc(downloadPattern, h.download)
+	h.mux.HandleFunc("DELETE /files/{id}", h.delete)
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth != nil && !h.signed(r) {
+		ctx, err := api.Authenticate(r.Context(), h.auth, headerMetadata(r.Header))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.writeError(w, err)
+			return
+		}
+		r = r.WithContext(ctx)
+	}
+
+	h.mux.ServeHTTP(w, r)
+}
+
+// signed reports whether r is a download or upload with a valid signed URL token,
+// which authorizes the request instead of credentials.
+func (h *Handler) signed(r *http.Request) bool {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		return false
+	}
+
+	var method file_svc_v1.SignedMethod
+	switch _, pattern := h.mux.Handler(r); pattern {
+	case uploadPattern:
+		method = file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD
+	case downloadPattern:
+		method = file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD
+	default:
+		return false
+	}
+
+	_, err := h.api.VerifyToken(token, method)
+	return err == nil
+}
+
+// headerMetadata converts HTTP headers to gRPC metadata with lowercase keys.
+func headerMetadata(header http.Header) metadata.MD {
+	md := make(metadata.MD, len(header))
+	for key, values := range header {
+		md.Append(key, values...)
+	}
+	return md
+}
+
+var jsonOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := jsonOptions.Marshal(msg)
+	if err != nil {
+		h.writeError(w, status.Errorf(codes.Internal, "cannot encode response: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// writeError replies with the HTTP status matching the gRPC code of err.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := httpStatus(st.Code())
+
+	if code == http.StatusInternalServerError {
+		h.log.Error("request failed", logs.Error(err))
+	}
+
+	body, _ := json.Marshal(errorResp{Error: st.Message()})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+type errorResp struct {
+	Error string `json:"error"`
+}
+
+var httpStatuses = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusRequestedRangeNotSatisfiable,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.ResourceExhausted:  http.StatusRequestEntityTooLarge,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Canceled:           499,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+func httpStatus(code codes.Code) int {
+	if status, ok := httpStatuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,657 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vishenosik/file-svc-sdk/api"
+	file_svc_v1 "github.com/vishenosik/file-svc-sdk/gen/grpc/v1/file_svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type memStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	infos map[string]*api.FileInfo
+	// query is the last ListFiles query.
+	query *api.ListQuery
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		files: make(map[string][]byte),
+		infos: make(map[string]*api.FileInfo),
+	}
+}
+
+func (ms *memStorage) UploadStream(_ context.Context, meta *api.UploadMeta, file io.Reader) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	id := fmt.Sprint(len(ms.files) + 1)
+	ms.files[id] = data
+	ms.infos[id] = &api.FileInfo{
+		ID:          id,
+		Size:        uint32(len(data)),
+		Filename:    meta.Filename,
+		ContentType: meta.ContentType,
+		Checksum:    meta.Checksum,
+		CreatedAt:   time.Now(),
+	}
+	return id, nil
+}
+
+func (ms *memStorage) DownloadStream(_ context.Context, id string) (io.ReadCloser, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	data, ok := ms.files[id]
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (ms *memStorage) DeleteFile(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.files[id]; !ok {
+		return api.ErrNotFound
+	}
+	delete(ms.files, id)
+	delete(ms.infos, id)
+	return nil
+}
+
+func (ms *memStorage) GetFileInfo(id string) (*api.FileInfo, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	info, ok := ms.infos[id]
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return info, nil
+}
+
+func (ms *memStorage) ListFiles(query *api.ListQuery) (*api.FileInfoList, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.query = query
+
+	list := &api.FileInfoList{}
+	for _, info := range ms.infos {
+		list.Files = append(list.Files, info)
+	}
+	list.Total = uint32(len(list.Files))
+	return list, nil
+}
+
+type testSettings struct{}
+
+func (testSettings) GetBatchSize() uint32   { return 1 << 16 }
+func (testSettings) GetMaxFileSize() uint32 { return 1 << 20 }
+
+func (testSettings) GetSigningKeys() []api.SigningKey {
+	return []api.SigningKey{{ID: "k1", Secret: []byte("secret")}}
+}
+
+func (testSettings) GetSignedURLBase() string { return "" }
+
+func TestHandler_ServeHTTP_Authentication(t *testing.T) {
+	storage := newMemStorage()
+	fsa := api.NewFileServiceStreamApi(storage, storage, testSettings{})
+	handler := NewHandler(fsa, WithAuthenticator(api.NewAPIKeyAuthenticator(map[string]string{"key": "alice"})))
+
+	ctx := context.Background()
+	id, err := storage.UploadStream(ctx, &api.UploadMeta{Filename: "victim.txt"}, strings.NewReader("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(id string, method file_svc_v1.SignedMethod) string {
+		signed, err := fsa.CreateSignedURL(ctx, &file_svc_v1.CreateSignedURLReq{Id: id, Method: method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed.GetToken()
+	}
+
+	download := sign(id, file_svc_v1.SignedMethod_SIGNED_METHOD_DOWNLOAD)
+	upload := sign("", file_svc_v1.SignedMethod_SIGNED_METHOD_UPLOAD)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		apiKey string
+		code   int
+	}{
+		{"list without credentials", http.MethodGet, "/files", "", http.StatusUnauthorized},
+		{"list with empty token", http.MethodGet, "/files?token=", "", http.StatusUnauthorized},
+		{"list with download token", http.MethodGet, "/files?token=" + download, "", http.StatusUnauthorized},
+		{"list with api key", http.MethodGet, "/files", "key", http.StatusOK},
+		{"list with wrong api key", http.MethodGet, "/files", "other", http.StatusUnauthorized},
+		{"download without credentials", http.MethodGet, "/files/" + id, "", http.StatusUnauthorized},
+		{"download with empty token", http.MethodGet, "/files/" + id + "?token=", "", http.StatusUnauthorized},
+		{"download with invalid token", http.MethodGet, "/files/" + id + "?token=x", "", http.StatusUnauthorized},
+		{"download with upload token", http.MethodGet, "/files/" + id + "?token=" + upload, "", http.StatusUnauthorized},
+		{"download with download token", http.MethodGet, "/files/" + id + "?token=" + download, "", http.StatusOK},
+		{"download with api key", http.MethodGet, "/files/" + id, "key", http.StatusOK},
+		{"upload with empty token", http.MethodPost, "/files?token=&filename=x", "", http.StatusUnauthorized},
+		{"upload with download token", http.MethodPost, "/files?filename=x&token=" + download, "", http.StatusUnauthorized},
+		{"upload with upload token", http.MethodPost, "/files?filename=x&token=" + upload, "", http.StatusCreated},
+		{"delete with invalid token", http.MethodDelete, "/files/" + id + "?token=x", "", http.StatusUnauthorized},
+		{"delete with download token", http.MethodDelete, "/files/" + id + "?token=" + download, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader("data"))
+			if tt.apiKey != "" {
+				r.Header.Set(api.APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+			if tt.code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("WWW-Authenticate header is not set")
+			}
+		})
+	}
+
+	if _, err := storage.GetFileInfo(id); err != nil {
+		t.Fatalf("file is deleted: %v", err)
+	}
+}
+
+func newTestHandler() (*Handler, *memStorage) {
+	storage := newMemStorage()
+	return NewHandler(api.NewFileServiceStreamApi(storage, storage, testSettings{})), storage
+}
+
+func serve(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func multipartBody(t *testing.T, field, filename, contentType, data string) (io.Reader, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("comment", "skipped"); err != nil {
+		t.Fatal(err)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, filename))
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(part, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return body, writer.FormDataContentType()
+}
+
+func TestHandler_Upload(t *testing.T) {
+	const data = `{"name":"report"}`
+
+	type request struct {
+		target      string
+		contentType string
+		body        io.Reader
+	}
+
+	raw := func(target, contentType string) func(t *testing.T) request {
+		return func(*testing.T) request {
+			return request{target: target, contentType: contentType, body: strings.NewReader(data)}
+		}
+	}
+	form := func(target, field, filename, contentType string) func(t *testing.T) request {
+		return func(t *testing.T) request {
+			body, formType := multipartBody(t, field, filename, contentType, data)
+			return request{target: target, contentType: formType, body: body}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		request     func(t *testing.T) request
+		code        int
+		filename    string
+		contentType string
+	}{
+		{
+			name:        "raw",
+			request:     raw("/files?filename=report.json", "text/plain"),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "text/plain",
+		},
+		{
+			name:        "raw without content type",
+			request:     raw("/files?filename=report.json", ""),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "application/json",
+		},
+		{
+			name:        "raw octet stream",
+			request:     raw("/files?filename=report.json", "application/octet-stream"),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "application/json",
+		},
+		{
+			name:        "raw form content type",
+			request:     raw("/files?filename=report.json", "application/x-www-form-urlencoded"),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "application/json",
+		},
+		{
+			name:    "raw without filename",
+			request: raw("/files", "text/plain"),
+			code:    http.StatusBadRequest,
+		},
+		{
+			name:    "raw invalid checksum",
+			request: raw("/files?filename=report.json&checksum=xyz", ""),
+			code:    http.StatusBadRequest,
+		},
+		{
+			name:    "raw invalid label",
+			request: raw("/files?filename=report.json&label=env", ""),
+			code:    http.StatusBadRequest,
+		},
+		{
+			name:        "multipart",
+			request:     form("/files", "file", "report.json", "text/plain"),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "text/plain",
+		},
+		{
+			name:        "multipart octet stream",
+			request:     form("/files", "file", "report.json", "application/octet-stream"),
+			code:        http.StatusCreated,
+			filename:    "report.json",
+			contentType: "application/json",
+		},
+		{
+			name:        "multipart filename override",
+			request:     form("/files?filename=renamed.json", "file", "report.json", ""),
+			code:        http.StatusCreated,
+			filename:    "renamed.json",
+			contentType: "application/json",
+		},
+		{
+			name:    "multipart without file field",
+			request: form("/files", "attachment", "report.json", ""),
+			code:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, storage := newTestHandler()
+
+			req := tt.request(t)
+			r := httptest.NewRequest(http.MethodPost, req.target, req.body)
+			if req.contentType != "" {
+				r.Header.Set("Content-Type", req.contentType)
+			}
+
+			w := serve(handler, r)
+			if w.Code != tt.code {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+			if tt.code != http.StatusCreated {
+				return
+			}
+
+			resp := &file_svc_v1.UploadStreamResp{}
+			if err := protojson.Unmarshal(w.Body.Bytes(), resp); err != nil {
+				t.Fatal(err)
+			}
+			if location := w.Header().Get("Location"); location != "/files/"+resp.GetId() {
+				t.Fatalf("got location %q", location)
+			}
+
+			info, err := storage.GetFileInfo(resp.GetId())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Filename != tt.filename {
+				t.Fatalf("got filename %q, want %q", info.Filename, tt.filename)
+			}
+			if info.ContentType != tt.contentType {
+				t.Fatalf("got content type %q, want %q", info.ContentType, tt.contentType)
+			}
+			if got := string(storage.files[resp.GetId()]); got != data {
+				t.Fatalf("got contents %q, want %q", got, data)
+			}
+		})
+	}
+}
+
+func TestHandler_Download(t *testing.T) {
+	handler, storage := newTestHandler()
+
+	const data = "hello world"
+	id, etag := uploadFile(t, handler, "отчёт.txt", data)
+
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		headers map[string]string
+		code    int
+		body    string
+		want    map[string]string
+	}{
+		{
+			name:   "full",
+			method: http.MethodGet,
+			target: "/files/" + id,
+			code:   http.StatusOK,
+			body:   data,
+			want: map[string]string{
+				"ETag":                etag,
+				"Content-Type":        "text/plain",
+				"Content-Length":      "11",
+				"Accept-Ranges":       "bytes",
+				"Content-Disposition": "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.txt",
+			},
+		},
+		{
+			name:   "head",
+			method: http.MethodHead,
+			target: "/files/" + id,
+			code:   http.StatusOK,
+			want:   map[string]string{"ETag": etag, "Content-Length": "11"},
+		},
+		{
+			name:    "range",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"Range": "bytes=0-4"},
+			code:    http.StatusPartialContent,
+			body:    "hello",
+			want:    map[string]string{"Content-Range": "bytes 0-4/11"},
+		},
+		{
+			name:    "range from offset",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"Range": "bytes=6-"},
+			code:    http.StatusPartialContent,
+			body:    "world",
+			want:    map[string]string{"Content-Range": "bytes 6-10/11"},
+		},
+		{
+			name:    "suffix range",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"Range": "bytes=-3"},
+			code:    http.StatusPartialContent,
+			body:    "rld",
+		},
+		{
+			name:    "range out of bounds",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"Range": "bytes=20-30"},
+			code:    http.StatusRequestedRangeNotSatisfiable,
+		},
+		{
+			name:    "matching etag",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"If-None-Match": etag},
+			code:    http.StatusNotModified,
+			want:    map[string]string{"ETag": etag},
+		},
+		{
+			name:    "other etag",
+			method:  http.MethodGet,
+			target:  "/files/" + id,
+			headers: map[string]string{"If-None-Match": `"other"`},
+			code:    http.StatusOK,
+			body:    data,
+		},
+		{
+			name:   "invalid version",
+			method: http.MethodGet,
+			target: "/files/" + id + "?version=latest",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown bucket",
+			method: http.MethodGet,
+			target: "/files/" + id + "?bucket=photos",
+			code:   http.StatusNotImplemented,
+		},
+		{
+			name:   "missing",
+			method: http.MethodGet,
+			target: "/files/missing",
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			w := serve(handler, r)
+			if w.Code != tt.code {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+			if tt.code < 300 && w.Body.String() != tt.body {
+				t.Fatalf("got body %q, want %q", w.Body, tt.body)
+			}
+			for key, value := range tt.want {
+				if got := w.Header().Get(key); got != value {
+					t.Fatalf("got %s %q, want %q", key, got, value)
+				}
+			}
+		})
+	}
+
+	if _, err := storage.GetFileInfo(id); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// uploadFile uploads a text file through handler, returning its id and ETag.
+func uploadFile(t *testing.T, handler http.Handler, filename, data string) (string, string) {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/files?filename="+url.QueryEscape(filename), strings.NewReader(data))
+	r.Header.Set("Content-Type", "text/plain")
+
+	w := serve(handler, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("upload got status %d: %s", w.Code, w.Body)
+	}
+
+	resp := &file_svc_v1.UploadStreamResp{}
+	if err := protojson.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte(data))
+	return resp.GetId(), strconv.Quote(hex.EncodeToString(sum[:]))
+}
+
+func TestHandler_Delete(t *testing.T) {
+	handler, _ := newTestHandler()
+	id, _ := uploadFile(t, handler, "report.txt", "data")
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		code   int
+	}{
+		{"invalid version", http.MethodDelete, "/files/" + id + "?version=x", http.StatusBadRequest},
+		{"delete", http.MethodDelete, "/files/" + id, http.StatusNoContent},
+		{"download deleted", http.MethodGet, "/files/" + id, http.StatusNotFound},
+		{"delete again", http.MethodDelete, "/files/" + id, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(handler, httptest.NewRequest(tt.method, tt.target, nil))
+			if w.Code != tt.code {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		code   int
+		query  *api.ListQuery
+	}{
+		{
+			name:   "defaults",
+			target: "/files",
+			code:   http.StatusOK,
+			query:  &api.ListQuery{PageSize: 100},
+		},
+		{
+			name:   "page",
+			target: "/files?page_size=10&page_token=next&prefix=rep",
+			code:   http.StatusOK,
+			query:  &api.ListQuery{PageSize: 10, PageToken: "next", FilenamePrefix: "rep"},
+		},
+		{
+			name:   "large page size",
+			target: "/files?page_size=1000000",
+			code:   http.StatusOK,
+			query:  &api.ListQuery{PageSize: 1000},
+		},
+		{name: "invalid page size", target: "/files?page_size=ten", code: http.StatusBadRequest},
+		{name: "negative page size", target: "/files?page_size=-1", code: http.StatusBadRequest},
+		{name: "invalid label selector", target: "/files?label_selector=env+in", code: http.StatusBadRequest},
+		{name: "label selector without label support", target: "/files?label_selector=env%3Dprod", code: http.StatusNotImplemented},
+		{name: "bucket without bucket support", target: "/files?bucket=photos", code: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, storage := newTestHandler()
+			uploadFile(t, handler, "report.txt", "data")
+
+			w := serve(handler, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.code {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+			if tt.query == nil {
+				return
+			}
+
+			if got := storage.query; got.PageSize != tt.query.PageSize ||
+				got.PageToken != tt.query.PageToken ||
+				got.FilenamePrefix != tt.query.FilenamePrefix {
+				t.Fatalf("got query %+v, want %+v", got, tt.query)
+			}
+
+			resp := &file_svc_v1.ListFilesResp{}
+			if err := protojson.Unmarshal(w.Body.Bytes(), resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GetFiles()) != 1 || resp.GetFiles()[0].GetFilename() != "report.txt" {
+				t.Fatalf("got files %v", resp.GetFiles())
+			}
+		})
+	}
+}
+
+func TestHandler_WriteError(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.OutOfRange, http.StatusRequestedRangeNotSatisfiable},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{codes.ResourceExhausted, http.StatusRequestEntityTooLarge},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.Canceled, 499},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.DataLoss, http.StatusInternalServerError},
+		{codes.Internal, http.StatusInternalServerError},
+	}
+
+	handler, _ := newTestHandler()
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.writeError(w, status.Error(tt.code, "failed"))
+
+			if w.Code != tt.want {
+				t.Fatalf("got status %d, want %d", w.Code, tt.want)
+			}
+			if body := w.Body.String(); body != `{"error":"failed"}` {
+				t.Fatalf("got body %s", body)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Fatalf("got content type %q", contentType)
+			}
+		})
+	}
+}
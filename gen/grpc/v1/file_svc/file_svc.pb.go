@@ -9,6 +9,9 @@ package file_svc_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,27 +24,1689 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DeleteResult int32
+
+const (
+	DeleteResult_DELETE_RESULT_UNSPECIFIED DeleteResult = 0
+	DeleteResult_DELETE_RESULT_DELETED     DeleteResult = 1
+	DeleteResult_DELETE_RESULT_NOT_FOUND   DeleteResult = 2
+	DeleteResult_DELETE_RESULT_FAILED      DeleteResult = 3
+)
+
+// Enum value maps for DeleteResult.
+var (
+	DeleteResult_name = map[int32]string{
+		0: "DELETE_RESULT_UNSPECIFIED",
+		1: "DELETE_RESULT_DELETED",
+		2: "DELETE_RESULT_NOT_FOUND",
+		3: "DELETE_RESULT_FAILED",
+	}
+	DeleteResult_value = map[string]int32{
+		"DELETE_RESULT_UNSPECIFIED": 0,
+		"DELETE_RESULT_DELETED":     1,
+		"DELETE_RESULT_NOT_FOUND":   2,
+		"DELETE_RESULT_FAILED":      3,
+	}
+)
+
+func (x DeleteResult) Enum() *DeleteResult {
+	p := new(DeleteResult)
+	*p = x
+	return p
+}
+
+func (x DeleteResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeleteResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_svc_proto_enumTypes[0].Descriptor()
+}
+
+func (DeleteResult) Type() protoreflect.EnumType {
+	return &file_file_svc_proto_enumTypes[0]
+}
+
+func (x DeleteResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeleteResult.Descriptor instead.
+func (DeleteResult) EnumDescriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{0}
+}
+
+type Permission int32
+
+const (
+	Permission_PERMISSION_UNSPECIFIED Permission = 0
+	Permission_PERMISSION_READ        Permission = 1
+	// PERMISSION_WRITE allows to read, update and delete the file.
+	Permission_PERMISSION_WRITE Permission = 2
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0: "PERMISSION_UNSPECIFIED",
+		1: "PERMISSION_READ",
+		2: "PERMISSION_WRITE",
+	}
+	Permission_value = map[string]int32{
+		"PERMISSION_UNSPECIFIED": 0,
+		"PERMISSION_READ":        1,
+		"PERMISSION_WRITE":       2,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_svc_proto_enumTypes[1].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_file_svc_proto_enumTypes[1]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{1}
+}
+
+type SortField int32
+
+const (
+	SortField_SORT_FIELD_UNSPECIFIED SortField = 0
+	SortField_SORT_FIELD_FILENAME    SortField = 1
+	SortField_SORT_FIELD_SIZE        SortField = 2
+	SortField_SORT_FIELD_CREATED_AT  SortField = 3
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_FILENAME",
+		2: "SORT_FIELD_SIZE",
+		3: "SORT_FIELD_CREATED_AT",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED": 0,
+		"SORT_FIELD_FILENAME":    1,
+		"SORT_FIELD_SIZE":        2,
+		"SORT_FIELD_CREATED_AT":  3,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_svc_proto_enumTypes[2].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_file_svc_proto_enumTypes[2]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{2}
+}
+
+type SignedMethod int32
+
+const (
+	SignedMethod_SIGNED_METHOD_UNSPECIFIED SignedMethod = 0
+	SignedMethod_SIGNED_METHOD_DOWNLOAD    SignedMethod = 1
+	SignedMethod_SIGNED_METHOD_UPLOAD      SignedMethod = 2
+)
+
+// Enum value maps for SignedMethod.
+var (
+	SignedMethod_name = map[int32]string{
+		0: "SIGNED_METHOD_UNSPECIFIED",
+		1: "SIGNED_METHOD_DOWNLOAD",
+		2: "SIGNED_METHOD_UPLOAD",
+	}
+	SignedMethod_value = map[string]int32{
+		"SIGNED_METHOD_UNSPECIFIED": 0,
+		"SIGNED_METHOD_DOWNLOAD":    1,
+		"SIGNED_METHOD_UPLOAD":      2,
+	}
+)
+
+func (x SignedMethod) Enum() *SignedMethod {
+	p := new(SignedMethod)
+	*p = x
+	return p
+}
+
+func (x SignedMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignedMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_svc_proto_enumTypes[3].Descriptor()
+}
+
+func (SignedMethod) Type() protoreflect.EnumType {
+	return &file_file_svc_proto_enumTypes[3]
+}
+
+func (x SignedMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignedMethod.Descriptor instead.
+func (SignedMethod) EnumDescriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{3}
+}
+
+// bucket is the name of the bucket on every request, empty for the default bucket.
 type ConstraintsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConstraintsReq) Reset() {
+	*x = ConstraintsReq{}
+	mi := &file_file_svc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConstraintsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConstraintsReq) ProtoMessage() {}
+
+func (x *ConstraintsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConstraintsReq.ProtoReflect.Descriptor instead.
+func (*ConstraintsReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConstraintsReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type ConstraintsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxBatchSize  uint32                 `protobuf:"varint,1,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxFileSize   uint32                 `protobuf:"varint,2,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConstraintsResp) Reset() {
+	*x = ConstraintsResp{}
+	mi := &file_file_svc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConstraintsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConstraintsResp) ProtoMessage() {}
+
+func (x *ConstraintsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConstraintsResp.ProtoReflect.Descriptor instead.
+func (*ConstraintsResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConstraintsResp) GetMaxBatchSize() uint32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ConstraintsResp) GetMaxFileSize() uint32 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+type UploadHeader struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Filename    string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        uint32                 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum    []byte                 `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// session_id appends the stream to an upload session at offset,
+	// other header fields are taken from the session.
+	SessionId string `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Offset    uint32 `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	Bucket    string `protobuf:"bytes,8,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// file_id uploads a new version of the file.
+	FileId string `protobuf:"bytes,9,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// new_version uploads a new version of the file with the same bucket and filename,
+	// creating the file when it does not exist.
+	NewVersion bool `protobuf:"varint,10,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	// expires_at or ttl sets when the file is deleted, only one of them may be set.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Ttl       *durationpb.Duration   `protobuf:"bytes,12,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// grants maps subjects to their permission on the file, "*" grants all callers.
+	// Versions keep the grants of the file unless set, which only the owner may do.
+	Grants map[string]Permission `protobuf:"bytes,13,rep,name=grants,proto3" json:"grants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=file_svc.v1.Permission"`
+	// token created by CreateSignedURL authorizes the upload, it selects bucket and file_id.
+	Token         string `protobuf:"bytes,14,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadHeader) Reset() {
+	*x = UploadHeader{}
+	mi := &file_file_svc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadHeader) ProtoMessage() {}
+
+func (x *UploadHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadHeader.ProtoReflect.Descriptor instead.
+func (*UploadHeader) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadHeader) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadHeader) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadHeader) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadHeader) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *UploadHeader) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UploadHeader) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UploadHeader) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadHeader) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *UploadHeader) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadHeader) GetNewVersion() bool {
+	if x != nil {
+		return x.NewVersion
+	}
+	return false
+}
+
+func (x *UploadHeader) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UploadHeader) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *UploadHeader) GetGrants() map[string]Permission {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
+func (x *UploadHeader) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type UploadStreamMsg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadStreamMsg_Header
+	//	*UploadStreamMsg_Chunk
+	Data          isUploadStreamMsg_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadStreamMsg) Reset() {
+	*x = UploadStreamMsg{}
+	mi := &file_file_svc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadStreamMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamMsg) ProtoMessage() {}
+
+func (x *UploadStreamMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamMsg.ProtoReflect.Descriptor instead.
+func (*UploadStreamMsg) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadStreamMsg) GetData() isUploadStreamMsg_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadStreamMsg) GetHeader() *UploadHeader {
+	if x != nil {
+		if x, ok := x.Data.(*UploadStreamMsg_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *UploadStreamMsg) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadStreamMsg_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadStreamMsg_Data interface {
+	isUploadStreamMsg_Data()
+}
+
+type UploadStreamMsg_Header struct {
+	Header *UploadHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type UploadStreamMsg_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadStreamMsg_Header) isUploadStreamMsg_Data() {}
+
+func (*UploadStreamMsg_Chunk) isUploadStreamMsg_Data() {}
+
+type UploadStreamResp struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size        uint32                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Checksum    []byte                 `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// committed is the number of bytes stored in the upload session.
+	Committed     uint32 `protobuf:"varint,5,opt,name=committed,proto3" json:"committed,omitempty"`
+	Version       uint32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadStreamResp) Reset() {
+	*x = UploadStreamResp{}
+	mi := &file_file_svc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadStreamResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamResp) ProtoMessage() {}
+
+func (x *UploadStreamResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamResp.ProtoReflect.Descriptor instead.
+func (*UploadStreamResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadStreamResp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UploadStreamResp) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadStreamResp) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadStreamResp) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *UploadStreamResp) GetCommitted() uint32 {
+	if x != nil {
+		return x.Committed
+	}
+	return 0
+}
+
+func (x *UploadStreamResp) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateUploadSessionReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *UploadHeader          `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionReq) Reset() {
+	*x = CreateUploadSessionReq{}
+	mi := &file_file_svc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionReq) ProtoMessage() {}
+
+func (x *CreateUploadSessionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionReq.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateUploadSessionReq) GetHeader() *UploadHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+type UploadSessionReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSessionReq) Reset() {
+	*x = UploadSessionReq{}
+	mi := &file_file_svc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSessionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSessionReq) ProtoMessage() {}
+
+func (x *UploadSessionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSessionReq.ProtoReflect.Descriptor instead.
+func (*UploadSessionReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UploadSessionReq) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type UploadSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Committed     uint32                 `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size          uint32                 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Bucket        string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
+	mi := &file_file_svc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSession) ProtoMessage() {}
+
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UploadSession) GetCommitted() uint32 {
+	if x != nil {
+		return x.Committed
+	}
+	return 0
+}
+
+func (x *UploadSession) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadSession) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadSession) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type FileReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Bucket string                 `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// version of the file, zero for the current version.
+	Version       uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileReq) Reset() {
+	*x = FileReq{}
+	mi := &file_file_svc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileReq) ProtoMessage() {}
+
+func (x *FileReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileReq.ProtoReflect.Descriptor instead.
+func (*FileReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *FileReq) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DownloadReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset uint32                 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// length is the number of bytes to read from offset, zero reads to the end of file.
+	Length uint32 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	Bucket string `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// version of the file, zero for the current version.
+	Version uint32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// token created by CreateSignedURL selects the file instead of id, bucket and version.
+	Token         string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadReq) Reset() {
+	*x = DownloadReq{}
+	mi := &file_file_svc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadReq) ProtoMessage() {}
+
+func (x *DownloadReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadReq.ProtoReflect.Descriptor instead.
+func (*DownloadReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DownloadReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DownloadReq) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadReq) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *DownloadReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DownloadReq) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DownloadReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type DownloadStreamMsg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chunk []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// info is sent in the first message of the stream, before the chunks.
+	Info          *FileInfoResp `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadStreamMsg) Reset() {
+	*x = DownloadStreamMsg{}
+	mi := &file_file_svc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadStreamMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadStreamMsg) ProtoMessage() {}
+
+func (x *DownloadStreamMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadStreamMsg.ProtoReflect.Descriptor instead.
+func (*DownloadStreamMsg) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DownloadStreamMsg) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *DownloadStreamMsg) GetInfo() *FileInfoResp {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type DeleteFileResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResp) Reset() {
+	*x = DeleteFileResp{}
+	mi := &file_file_svc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResp) ProtoMessage() {}
+
+func (x *DeleteFileResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResp.ProtoReflect.Descriptor instead.
+func (*DeleteFileResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{11}
+}
+
+type DeleteFilesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Bucket        string                 `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFilesReq) Reset() {
+	*x = DeleteFilesReq{}
+	mi := &file_file_svc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFilesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFilesReq) ProtoMessage() {}
+
+func (x *DeleteFilesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFilesReq.ProtoReflect.Descriptor instead.
+func (*DeleteFilesReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteFilesReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *DeleteFilesReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type DeleteFileResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result DeleteResult           `protobuf:"varint,2,opt,name=result,proto3,enum=file_svc.v1.DeleteResult" json:"result,omitempty"`
+	// code and message hold the status of a failed delete.
+	Code          int32  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResult) Reset() {
+	*x = DeleteFileResult{}
+	mi := &file_file_svc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResult) ProtoMessage() {}
+
+func (x *DeleteFileResult) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResult.ProtoReflect.Descriptor instead.
+func (*DeleteFileResult) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteFileResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteFileResult) GetResult() DeleteResult {
+	if x != nil {
+		return x.Result
+	}
+	return DeleteResult_DELETE_RESULT_UNSPECIFIED
+}
+
+func (x *DeleteFileResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *DeleteFileResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteFilesResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// results are in the order of the requested ids.
+	Results       []*DeleteFileResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFilesResp) Reset() {
+	*x = DeleteFilesResp{}
+	mi := &file_file_svc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFilesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFilesResp) ProtoMessage() {}
+
+func (x *DeleteFilesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFilesResp.ProtoReflect.Descriptor instead.
+func (*DeleteFilesResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteFilesResp) GetResults() []*DeleteFileResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type FileInfoResp struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size        uint32                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Filename    string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Checksum    []byte                 `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Bucket      string                 `protobuf:"bytes,8,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Version     uint32                 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// deleted_at is set for files in trash.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// owner is the subject of the caller which uploaded the file.
+	Owner         string                `protobuf:"bytes,12,opt,name=owner,proto3" json:"owner,omitempty"`
+	Grants        map[string]Permission `protobuf:"bytes,13,rep,name=grants,proto3" json:"grants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=file_svc.v1.Permission"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfoResp) Reset() {
+	*x = FileInfoResp{}
+	mi := &file_file_svc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfoResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfoResp) ProtoMessage() {}
+
+func (x *FileInfoResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfoResp.ProtoReflect.Descriptor instead.
+func (*FileInfoResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FileInfoResp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileInfoResp) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfoResp) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *FileInfoResp) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileInfoResp) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *FileInfoResp) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FileInfoResp) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *FileInfoResp) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *FileInfoResp) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FileInfoResp) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *FileInfoResp) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *FileInfoResp) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *FileInfoResp) GetGrants() map[string]Permission {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
+type UpdateFileLabelsReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// labels are added to the file, replacing values of existing keys.
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// remove lists keys of labels to remove from the file.
+	Remove        []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	Bucket        string   `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileLabelsReq) Reset() {
+	*x = UpdateFileLabelsReq{}
+	mi := &file_file_svc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileLabelsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileLabelsReq) ProtoMessage() {}
+
+func (x *UpdateFileLabelsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileLabelsReq.ProtoReflect.Descriptor instead.
+func (*UpdateFileLabelsReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateFileLabelsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateFileLabelsReq) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateFileLabelsReq) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *UpdateFileLabelsReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type UpdateFileInfoReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Info  *FileInfoResp          `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// update_mask lists the info fields to update: filename, content_type, labels and grants.
+	// labels and grants replace all labels and grants of the file, only the owner may update grants.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Bucket        string                 `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileInfoReq) Reset() {
+	*x = UpdateFileInfoReq{}
+	mi := &file_file_svc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileInfoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileInfoReq) ProtoMessage() {}
+
+func (x *UpdateFileInfoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileInfoReq.ProtoReflect.Descriptor instead.
+func (*UpdateFileInfoReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateFileInfoReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateFileInfoReq) GetInfo() *FileInfoResp {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *UpdateFileInfoReq) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateFileInfoReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type CopyFileReq struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SourceId string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	// filename of the copy, the source filename is kept when empty.
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// bucket of the source file, the copy is created in the same bucket.
+	Bucket        string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyFileReq) Reset() {
+	*x = CopyFileReq{}
+	mi := &file_file_svc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFileReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileReq) ProtoMessage() {}
+
+func (x *CopyFileReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileReq.ProtoReflect.Descriptor instead.
+func (*CopyFileReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CopyFileReq) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *CopyFileReq) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CopyFileReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type ListFilesReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PageSize       uint32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	FilenamePrefix string                 `protobuf:"bytes,3,opt,name=filename_prefix,json=filenamePrefix,proto3" json:"filename_prefix,omitempty"`
+	// min_size and max_size bound the file size, zero values are not applied.
+	MinSize       uint32                 `protobuf:"varint,4,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+	MaxSize       uint32                 `protobuf:"varint,5,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	SortBy        SortField              `protobuf:"varint,8,opt,name=sort_by,json=sortBy,proto3,enum=file_svc.v1.SortField" json:"sort_by,omitempty"`
+	Descending    bool                   `protobuf:"varint,9,opt,name=descending,proto3" json:"descending,omitempty"`
+	// label_selector filters files by labels, e.g. "tenant=acme,kind in (invoice,receipt),!draft".
+	LabelSelector string `protobuf:"bytes,10,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	Bucket        string `protobuf:"bytes,11,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesReq) Reset() {
+	*x = ListFilesReq{}
+	mi := &file_file_svc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesReq) ProtoMessage() {}
+
+func (x *ListFilesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesReq.ProtoReflect.Descriptor instead.
+func (*ListFilesReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListFilesReq) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFilesReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListFilesReq) GetFilenamePrefix() string {
+	if x != nil {
+		return x.FilenamePrefix
+	}
+	return ""
+}
+
+func (x *ListFilesReq) GetMinSize() uint32 {
+	if x != nil {
+		return x.MinSize
+	}
+	return 0
+}
+
+func (x *ListFilesReq) GetMaxSize() uint32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *ListFilesReq) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListFilesReq) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListFilesReq) GetSortBy() SortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListFilesReq) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListFilesReq) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ListFilesReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type ListVersionsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*FileInfoResp        `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConstraintsReq) Reset() {
-	*x = ConstraintsReq{}
-	mi := &file_file_svc_proto_msgTypes[0]
+func (x *ListVersionsResp) Reset() {
+	*x = ListVersionsResp{}
+	mi := &file_file_svc_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConstraintsReq) String() string {
+func (x *ListVersionsResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConstraintsReq) ProtoMessage() {}
+func (*ListVersionsResp) ProtoMessage() {}
 
-func (x *ConstraintsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[0]
+func (x *ListVersionsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -52,34 +1717,44 @@ func (x *ConstraintsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConstraintsReq.ProtoReflect.Descriptor instead.
-func (*ConstraintsReq) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ListVersionsResp.ProtoReflect.Descriptor instead.
+func (*ListVersionsResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{20}
 }
 
-type ConstraintsResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxBatchSize  uint32                 `protobuf:"varint,1,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
-	MaxFileSize   uint32                 `protobuf:"varint,2,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+func (x *ListVersionsResp) GetVersions() []*FileInfoResp {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type ListFilesResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total is zero when access control hides files of the page.
+	Total uint32          `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Files []*FileInfoResp `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConstraintsResp) Reset() {
-	*x = ConstraintsResp{}
-	mi := &file_file_svc_proto_msgTypes[1]
+func (x *ListFilesResp) Reset() {
+	*x = ListFilesResp{}
+	mi := &file_file_svc_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConstraintsResp) String() string {
+func (x *ListFilesResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConstraintsResp) ProtoMessage() {}
+func (*ListFilesResp) ProtoMessage() {}
 
-func (x *ConstraintsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[1]
+func (x *ListFilesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -90,47 +1765,58 @@ func (x *ConstraintsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConstraintsResp.ProtoReflect.Descriptor instead.
-func (*ConstraintsResp) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListFilesResp.ProtoReflect.Descriptor instead.
+func (*ListFilesResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ConstraintsResp) GetMaxBatchSize() uint32 {
+func (x *ListFilesResp) GetTotal() uint32 {
 	if x != nil {
-		return x.MaxBatchSize
+		return x.Total
 	}
 	return 0
 }
 
-func (x *ConstraintsResp) GetMaxFileSize() uint32 {
+func (x *ListFilesResp) GetFiles() []*FileInfoResp {
 	if x != nil {
-		return x.MaxFileSize
+		return x.Files
 	}
-	return 0
+	return nil
 }
 
-type UploadStreamMsg struct {
+func (x *ListFilesResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Bucket limits override the server settings, zero limits are inherited.
+type Bucket struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxFileSize   uint32                 `protobuf:"varint,2,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	MaxBatchSize  uint32                 `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadStreamMsg) Reset() {
-	*x = UploadStreamMsg{}
-	mi := &file_file_svc_proto_msgTypes[2]
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	mi := &file_file_svc_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadStreamMsg) String() string {
+func (x *Bucket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadStreamMsg) ProtoMessage() {}
+func (*Bucket) ProtoMessage() {}
 
-func (x *UploadStreamMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[2]
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,41 +1827,63 @@ func (x *UploadStreamMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadStreamMsg.ProtoReflect.Descriptor instead.
-func (*UploadStreamMsg) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UploadStreamMsg) GetChunk() []byte {
+func (x *Bucket) GetName() string {
 	if x != nil {
-		return x.Chunk
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bucket) GetMaxFileSize() uint32 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *Bucket) GetMaxBatchSize() uint32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *Bucket) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type UploadStreamResp struct {
+type CreateBucketReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Size          uint32                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxFileSize   uint32                 `protobuf:"varint,2,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	MaxBatchSize  uint32                 `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadStreamResp) Reset() {
-	*x = UploadStreamResp{}
-	mi := &file_file_svc_proto_msgTypes[3]
+func (x *CreateBucketReq) Reset() {
+	*x = CreateBucketReq{}
+	mi := &file_file_svc_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadStreamResp) String() string {
+func (x *CreateBucketReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadStreamResp) ProtoMessage() {}
+func (*CreateBucketReq) ProtoMessage() {}
 
-func (x *UploadStreamResp) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[3]
+func (x *CreateBucketReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -186,47 +1894,54 @@ func (x *UploadStreamResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadStreamResp.ProtoReflect.Descriptor instead.
-func (*UploadStreamResp) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CreateBucketReq.ProtoReflect.Descriptor instead.
+func (*CreateBucketReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *UploadStreamResp) GetId() string {
+func (x *CreateBucketReq) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UploadStreamResp) GetSize() uint32 {
+func (x *CreateBucketReq) GetMaxFileSize() uint32 {
 	if x != nil {
-		return x.Size
+		return x.MaxFileSize
 	}
 	return 0
 }
 
-type FileReq struct {
+func (x *CreateBucketReq) GetMaxBatchSize() uint32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+type BucketReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileReq) Reset() {
-	*x = FileReq{}
-	mi := &file_file_svc_proto_msgTypes[4]
+func (x *BucketReq) Reset() {
+	*x = BucketReq{}
+	mi := &file_file_svc_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileReq) String() string {
+func (x *BucketReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileReq) ProtoMessage() {}
+func (*BucketReq) ProtoMessage() {}
 
-func (x *FileReq) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[4]
+func (x *BucketReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,40 +1952,39 @@ func (x *FileReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileReq.ProtoReflect.Descriptor instead.
-func (*FileReq) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use BucketReq.ProtoReflect.Descriptor instead.
+func (*BucketReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *FileReq) GetId() string {
+func (x *BucketReq) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type DownloadStreamMsg struct {
+type DeleteBucketResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadStreamMsg) Reset() {
-	*x = DownloadStreamMsg{}
-	mi := &file_file_svc_proto_msgTypes[5]
+func (x *DeleteBucketResp) Reset() {
+	*x = DeleteBucketResp{}
+	mi := &file_file_svc_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadStreamMsg) String() string {
+func (x *DeleteBucketResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadStreamMsg) ProtoMessage() {}
+func (*DeleteBucketResp) ProtoMessage() {}
 
-func (x *DownloadStreamMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[5]
+func (x *DeleteBucketResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,39 +1995,32 @@ func (x *DownloadStreamMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadStreamMsg.ProtoReflect.Descriptor instead.
-func (*DownloadStreamMsg) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *DownloadStreamMsg) GetChunk() []byte {
-	if x != nil {
-		return x.Chunk
-	}
-	return nil
+// Deprecated: Use DeleteBucketResp.ProtoReflect.Descriptor instead.
+func (*DeleteBucketResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{25}
 }
 
-type DeleteFileResp struct {
+type ListBucketsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileResp) Reset() {
-	*x = DeleteFileResp{}
-	mi := &file_file_svc_proto_msgTypes[6]
+func (x *ListBucketsReq) Reset() {
+	*x = ListBucketsReq{}
+	mi := &file_file_svc_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileResp) String() string {
+func (x *ListBucketsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileResp) ProtoMessage() {}
+func (*ListBucketsReq) ProtoMessage() {}
 
-func (x *DeleteFileResp) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[6]
+func (x *ListBucketsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,35 +2031,33 @@ func (x *DeleteFileResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileResp.ProtoReflect.Descriptor instead.
-func (*DeleteFileResp) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListBucketsReq.ProtoReflect.Descriptor instead.
+func (*ListBucketsReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{26}
 }
 
-type FileInfoResp struct {
+type ListBucketsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Size          uint32                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Buckets       []*Bucket              `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileInfoResp) Reset() {
-	*x = FileInfoResp{}
-	mi := &file_file_svc_proto_msgTypes[7]
+func (x *ListBucketsResp) Reset() {
+	*x = ListBucketsResp{}
+	mi := &file_file_svc_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileInfoResp) String() string {
+func (x *ListBucketsResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileInfoResp) ProtoMessage() {}
+func (*ListBucketsResp) ProtoMessage() {}
 
-func (x *FileInfoResp) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[7]
+func (x *ListBucketsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,53 +2068,48 @@ func (x *FileInfoResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileInfoResp.ProtoReflect.Descriptor instead.
-func (*FileInfoResp) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *FileInfoResp) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *FileInfoResp) GetSize() uint32 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
+// Deprecated: Use ListBucketsResp.ProtoReflect.Descriptor instead.
+func (*ListBucketsResp) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *FileInfoResp) GetFilename() string {
+func (x *ListBucketsResp) GetBuckets() []*Bucket {
 	if x != nil {
-		return x.Filename
+		return x.Buckets
 	}
-	return ""
+	return nil
 }
 
-type ListFilesReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type CreateSignedURLReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the file to download or to upload a new version of,
+	// upload URLs without id create new files.
+	Id     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method SignedMethod `protobuf:"varint,2,opt,name=method,proto3,enum=file_svc.v1.SignedMethod" json:"method,omitempty"`
+	// expires_in is how long the URL is valid, the server default when unset.
+	ExpiresIn *durationpb.Duration `protobuf:"bytes,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	Bucket    string               `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// version pins download URLs to a version of the file.
+	Version       uint32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesReq) Reset() {
-	*x = ListFilesReq{}
-	mi := &file_file_svc_proto_msgTypes[8]
+func (x *CreateSignedURLReq) Reset() {
+	*x = CreateSignedURLReq{}
+	mi := &file_file_svc_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesReq) String() string {
+func (x *CreateSignedURLReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesReq) ProtoMessage() {}
+func (*CreateSignedURLReq) ProtoMessage() {}
 
-func (x *ListFilesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[8]
+func (x *CreateSignedURLReq) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,34 +2120,71 @@ func (x *ListFilesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesReq.ProtoReflect.Descriptor instead.
-func (*ListFilesReq) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CreateSignedURLReq.ProtoReflect.Descriptor instead.
+func (*CreateSignedURLReq) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{28}
 }
 
-type ListFilesResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Total         uint32                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Files         []*FileInfoResp        `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+func (x *CreateSignedURLReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateSignedURLReq) GetMethod() SignedMethod {
+	if x != nil {
+		return x.Method
+	}
+	return SignedMethod_SIGNED_METHOD_UNSPECIFIED
+}
+
+func (x *CreateSignedURLReq) GetExpiresIn() *durationpb.Duration {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return nil
+}
+
+func (x *CreateSignedURLReq) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *CreateSignedURLReq) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SignedURL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// url of the HTTP gateway, relative unless the server is configured with its base URL.
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesResp) Reset() {
-	*x = ListFilesResp{}
-	mi := &file_file_svc_proto_msgTypes[9]
+func (x *SignedURL) Reset() {
+	*x = SignedURL{}
+	mi := &file_file_svc_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesResp) String() string {
+func (x *SignedURL) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesResp) ProtoMessage() {}
+func (*SignedURL) ProtoMessage() {}
 
-func (x *ListFilesResp) ProtoReflect() protoreflect.Message {
-	mi := &file_file_svc_proto_msgTypes[9]
+func (x *SignedURL) ProtoReflect() protoreflect.Message {
+	mi := &file_file_svc_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,21 +2195,28 @@ func (x *ListFilesResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesResp.ProtoReflect.Descriptor instead.
-func (*ListFilesResp) Descriptor() ([]byte, []int) {
-	return file_file_svc_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SignedURL.ProtoReflect.Descriptor instead.
+func (*SignedURL) Descriptor() ([]byte, []int) {
+	return file_file_svc_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ListFilesResp) GetTotal() uint32 {
+func (x *SignedURL) GetToken() string {
 	if x != nil {
-		return x.Total
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *ListFilesResp) GetFiles() []*FileInfoResp {
+func (x *SignedURL) GetUrl() string {
 	if x != nil {
-		return x.Files
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SignedURL) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return nil
 }
@@ -481,37 +2225,218 @@ var File_file_svc_proto protoreflect.FileDescriptor
 
 const file_file_svc_proto_rawDesc = "" +
 	"\n" +
-	"\x0efile_svc.proto\x12\vfile_svc.v1\"\x10\n" +
-	"\x0eConstraintsReq\"[\n" +
+	"\x0efile_svc.proto\x12\vfile_svc.v1\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"(\n" +
+	"\x0eConstraintsReq\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\"[\n" +
 	"\x0fConstraintsResp\x12$\n" +
 	"\x0emax_batch_size\x18\x01 \x01(\rR\fmaxBatchSize\x12\"\n" +
-	"\rmax_file_size\x18\x02 \x01(\rR\vmaxFileSize\"'\n" +
-	"\x0fUploadStreamMsg\x12\x14\n" +
-	"\x05chunk\x18\x02 \x01(\fR\x05chunk\"6\n" +
+	"\rmax_file_size\x18\x02 \x01(\rR\vmaxFileSize\"\x91\x05\n" +
+	"\fUploadHeader\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\rR\x04size\x12\x1a\n" +
+	"\bchecksum\x18\x04 \x01(\fR\bchecksum\x12=\n" +
+	"\x06labels\x18\x05 \x03(\v2%.file_svc.v1.UploadHeader.LabelsEntryR\x06labels\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x06 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06offset\x18\a \x01(\rR\x06offset\x12\x16\n" +
+	"\x06bucket\x18\b \x01(\tR\x06bucket\x12\x17\n" +
+	"\afile_id\x18\t \x01(\tR\x06fileId\x12\x1f\n" +
+	"\vnew_version\x18\n" +
+	" \x01(\bR\n" +
+	"newVersion\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12+\n" +
+	"\x03ttl\x18\f \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12=\n" +
+	"\x06grants\x18\r \x03(\v2%.file_svc.v1.UploadHeader.GrantsEntryR\x06grants\x12\x14\n" +
+	"\x05token\x18\x0e \x01(\tR\x05token\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aR\n" +
+	"\vGrantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x17.file_svc.v1.PermissionR\x05value:\x028\x01\"f\n" +
+	"\x0fUploadStreamMsg\x123\n" +
+	"\x06header\x18\x01 \x01(\v2\x19.file_svc.v1.UploadHeaderH\x00R\x06header\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\xad\x01\n" +
 	"\x10UploadStreamResp\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\rR\x04size\"\x19\n" +
+	"\x04size\x18\x02 \x01(\rR\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bchecksum\x18\x04 \x01(\fR\bchecksum\x12\x1c\n" +
+	"\tcommitted\x18\x05 \x01(\rR\tcommitted\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\rR\aversion\"K\n" +
+	"\x16CreateUploadSessionReq\x121\n" +
+	"\x06header\x18\x01 \x01(\v2\x19.file_svc.v1.UploadHeaderR\x06header\"1\n" +
+	"\x10UploadSessionReq\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x94\x01\n" +
+	"\rUploadSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1c\n" +
+	"\tcommitted\x18\x02 \x01(\rR\tcommitted\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\rR\x04size\x12\x16\n" +
+	"\x06bucket\x18\x05 \x01(\tR\x06bucket\"K\n" +
 	"\aFileReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\")\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\"\x95\x01\n" +
+	"\vDownloadReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\rR\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\rR\x06length\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\rR\aversion\x12\x14\n" +
+	"\x05token\x18\x06 \x01(\tR\x05token\"X\n" +
 	"\x11DownloadStreamMsg\x12\x14\n" +
-	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"\x10\n" +
-	"\x0eDeleteFileResp\"N\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12-\n" +
+	"\x04info\x18\x02 \x01(\v2\x19.file_svc.v1.FileInfoRespR\x04info\"\x10\n" +
+	"\x0eDeleteFileResp\":\n" +
+	"\x0eDeleteFilesReq\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\"\x83\x01\n" +
+	"\x10DeleteFileResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x06result\x18\x02 \x01(\x0e2\x19.file_svc.v1.DeleteResultR\x06result\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"J\n" +
+	"\x0fDeleteFilesResp\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.file_svc.v1.DeleteFileResultR\aresults\"\x93\x05\n" +
 	"\fFileInfoResp\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\rR\x04size\x12\x1a\n" +
-	"\bfilename\x18\x03 \x01(\tR\bfilename\"\x0e\n" +
-	"\fListFilesReq\"V\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\fR\bchecksum\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\x06labels\x18\a \x03(\v2%.file_svc.v1.FileInfoResp.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06bucket\x18\b \x01(\tR\x06bucket\x12\x18\n" +
+	"\aversion\x18\t \x01(\rR\aversion\x129\n" +
+	"\n" +
+	"deleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x14\n" +
+	"\x05owner\x18\f \x01(\tR\x05owner\x12=\n" +
+	"\x06grants\x18\r \x03(\v2%.file_svc.v1.FileInfoResp.GrantsEntryR\x06grants\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aR\n" +
+	"\vGrantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x17.file_svc.v1.PermissionR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\x13UpdateFileLabelsReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12D\n" +
+	"\x06labels\x18\x02 \x03(\v2,.file_svc.v1.UpdateFileLabelsReq.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06remove\x18\x03 \x03(\tR\x06remove\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x01\n" +
+	"\x11UpdateFileInfoReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x04info\x18\x02 \x01(\v2\x19.file_svc.v1.FileInfoRespR\x04info\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\"^\n" +
+	"\vCopyFileReq\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\"\xbd\x03\n" +
+	"\fListFilesReq\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12'\n" +
+	"\x0ffilename_prefix\x18\x03 \x01(\tR\x0efilenamePrefix\x12\x19\n" +
+	"\bmin_size\x18\x04 \x01(\rR\aminSize\x12\x19\n" +
+	"\bmax_size\x18\x05 \x01(\rR\amaxSize\x12?\n" +
+	"\rcreated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12/\n" +
+	"\asort_by\x18\b \x01(\x0e2\x16.file_svc.v1.SortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\t \x01(\bR\n" +
+	"descending\x12%\n" +
+	"\x0elabel_selector\x18\n" +
+	" \x01(\tR\rlabelSelector\x12\x16\n" +
+	"\x06bucket\x18\v \x01(\tR\x06bucket\"I\n" +
+	"\x10ListVersionsResp\x125\n" +
+	"\bversions\x18\x01 \x03(\v2\x19.file_svc.v1.FileInfoRespR\bversions\"~\n" +
 	"\rListFilesResp\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\rR\x05total\x12/\n" +
-	"\x05files\x18\x02 \x03(\v2\x19.file_svc.v1.FileInfoRespR\x05files2\xb5\x03\n" +
+	"\x05files\x18\x02 \x03(\v2\x19.file_svc.v1.FileInfoRespR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xa1\x01\n" +
+	"\x06Bucket\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\rmax_file_size\x18\x02 \x01(\rR\vmaxFileSize\x12$\n" +
+	"\x0emax_batch_size\x18\x03 \x01(\rR\fmaxBatchSize\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"o\n" +
+	"\x0fCreateBucketReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\rmax_file_size\x18\x02 \x01(\rR\vmaxFileSize\x12$\n" +
+	"\x0emax_batch_size\x18\x03 \x01(\rR\fmaxBatchSize\"\x1f\n" +
+	"\tBucketReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x12\n" +
+	"\x10DeleteBucketResp\"\x10\n" +
+	"\x0eListBucketsReq\"@\n" +
+	"\x0fListBucketsResp\x12-\n" +
+	"\abuckets\x18\x01 \x03(\v2\x13.file_svc.v1.BucketR\abuckets\"\xc3\x01\n" +
+	"\x12CreateSignedURLReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x06method\x18\x02 \x01(\x0e2\x19.file_svc.v1.SignedMethodR\x06method\x128\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\texpiresIn\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\rR\aversion\"n\n" +
+	"\tSignedURL\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt*\x7f\n" +
+	"\fDeleteResult\x12\x1d\n" +
+	"\x19DELETE_RESULT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15DELETE_RESULT_DELETED\x10\x01\x12\x1b\n" +
+	"\x17DELETE_RESULT_NOT_FOUND\x10\x02\x12\x18\n" +
+	"\x14DELETE_RESULT_FAILED\x10\x03*S\n" +
+	"\n" +
+	"Permission\x12\x1a\n" +
+	"\x16PERMISSION_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fPERMISSION_READ\x10\x01\x12\x14\n" +
+	"\x10PERMISSION_WRITE\x10\x02*p\n" +
+	"\tSortField\x12\x1a\n" +
+	"\x16SORT_FIELD_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SORT_FIELD_FILENAME\x10\x01\x12\x13\n" +
+	"\x0fSORT_FIELD_SIZE\x10\x02\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x03*c\n" +
+	"\fSignedMethod\x12\x1d\n" +
+	"\x19SIGNED_METHOD_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16SIGNED_METHOD_DOWNLOAD\x10\x01\x12\x18\n" +
+	"\x14SIGNED_METHOD_UPLOAD\x10\x022\xc0\v\n" +
 	"\vFileService\x12H\n" +
 	"\vConstraints\x12\x1b.file_svc.v1.ConstraintsReq\x1a\x1c.file_svc.v1.ConstraintsResp\x12M\n" +
-	"\fUploadStream\x12\x1c.file_svc.v1.UploadStreamMsg\x1a\x1d.file_svc.v1.UploadStreamResp(\x01\x12H\n" +
-	"\x0eDownloadStream\x12\x14.file_svc.v1.FileReq\x1a\x1e.file_svc.v1.DownloadStreamMsg0\x01\x12?\n" +
+	"\fUploadStream\x12\x1c.file_svc.v1.UploadStreamMsg\x1a\x1d.file_svc.v1.UploadStreamResp(\x01\x12L\n" +
+	"\x0eDownloadStream\x12\x18.file_svc.v1.DownloadReq\x1a\x1e.file_svc.v1.DownloadStreamMsg0\x01\x12?\n" +
 	"\n" +
-	"DeleteFile\x12\x14.file_svc.v1.FileReq\x1a\x1b.file_svc.v1.DeleteFileResp\x12>\n" +
+	"DeleteFile\x12\x14.file_svc.v1.FileReq\x1a\x1b.file_svc.v1.DeleteFileResp\x12H\n" +
+	"\vDeleteFiles\x12\x1b.file_svc.v1.DeleteFilesReq\x1a\x1c.file_svc.v1.DeleteFilesResp\x12>\n" +
 	"\vGetFileInfo\x12\x14.file_svc.v1.FileReq\x1a\x19.file_svc.v1.FileInfoResp\x12B\n" +
-	"\tListFiles\x12\x19.file_svc.v1.ListFilesReq\x1a\x1a.file_svc.v1.ListFilesRespB0Z.github.com/vishenosik/file-svc-sdk;file_svc_v1b\x06proto3"
+	"\tListFiles\x12\x19.file_svc.v1.ListFilesReq\x1a\x1a.file_svc.v1.ListFilesResp\x12O\n" +
+	"\x10UpdateFileLabels\x12 .file_svc.v1.UpdateFileLabelsReq\x1a\x19.file_svc.v1.FileInfoResp\x12K\n" +
+	"\x0eUpdateFileInfo\x12\x1e.file_svc.v1.UpdateFileInfoReq\x1a\x19.file_svc.v1.FileInfoResp\x12?\n" +
+	"\bCopyFile\x12\x18.file_svc.v1.CopyFileReq\x1a\x19.file_svc.v1.FileInfoResp\x12A\n" +
+	"\fCreateBucket\x12\x1c.file_svc.v1.CreateBucketReq\x1a\x13.file_svc.v1.Bucket\x12E\n" +
+	"\fDeleteBucket\x12\x16.file_svc.v1.BucketReq\x1a\x1d.file_svc.v1.DeleteBucketResp\x12H\n" +
+	"\vListBuckets\x12\x1b.file_svc.v1.ListBucketsReq\x1a\x1c.file_svc.v1.ListBucketsResp\x12C\n" +
+	"\fListVersions\x12\x14.file_svc.v1.FileReq\x1a\x1d.file_svc.v1.ListVersionsResp\x12>\n" +
+	"\vRestoreFile\x12\x14.file_svc.v1.FileReq\x1a\x19.file_svc.v1.FileInfoResp\x12B\n" +
+	"\tListTrash\x12\x19.file_svc.v1.ListFilesReq\x1a\x1a.file_svc.v1.ListFilesResp\x12J\n" +
+	"\x0fCreateSignedURL\x12\x1f.file_svc.v1.CreateSignedURLReq\x1a\x16.file_svc.v1.SignedURL\x12V\n" +
+	"\x13CreateUploadSession\x12#.file_svc.v1.CreateUploadSessionReq\x1a\x1a.file_svc.v1.UploadSession\x12M\n" +
+	"\x10GetUploadSession\x12\x1d.file_svc.v1.UploadSessionReq\x1a\x1a.file_svc.v1.UploadSession\x12L\n" +
+	"\fCommitUpload\x12\x1d.file_svc.v1.UploadSessionReq\x1a\x1d.file_svc.v1.UploadStreamRespB0Z.github.com/vishenosik/file-svc-sdk;file_svc_v1b\x06proto3"
 
 var (
 	file_file_svc_proto_rawDescOnce sync.Once
@@ -525,38 +2450,127 @@ func file_file_svc_proto_rawDescGZIP() []byte {
 	return file_file_svc_proto_rawDescData
 }
 
-var file_file_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_file_svc_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_file_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_file_svc_proto_goTypes = []any{
-	(*ConstraintsReq)(nil),    // 0: file_svc.v1.ConstraintsReq
-	(*ConstraintsResp)(nil),   // 1: file_svc.v1.ConstraintsResp
-	(*UploadStreamMsg)(nil),   // 2: file_svc.v1.UploadStreamMsg
-	(*UploadStreamResp)(nil),  // 3: file_svc.v1.UploadStreamResp
-	(*FileReq)(nil),           // 4: file_svc.v1.FileReq
-	(*DownloadStreamMsg)(nil), // 5: file_svc.v1.DownloadStreamMsg
-	(*DeleteFileResp)(nil),    // 6: file_svc.v1.DeleteFileResp
-	(*FileInfoResp)(nil),      // 7: file_svc.v1.FileInfoResp
-	(*ListFilesReq)(nil),      // 8: file_svc.v1.ListFilesReq
-	(*ListFilesResp)(nil),     // 9: file_svc.v1.ListFilesResp
+	(DeleteResult)(0),              // 0: file_svc.v1.DeleteResult
+	(Permission)(0),                // 1: file_svc.v1.Permission
+	(SortField)(0),                 // 2: file_svc.v1.SortField
+	(SignedMethod)(0),              // 3: file_svc.v1.SignedMethod
+	(*ConstraintsReq)(nil),         // 4: file_svc.v1.ConstraintsReq
+	(*ConstraintsResp)(nil),        // 5: file_svc.v1.ConstraintsResp
+	(*UploadHeader)(nil),           // 6: file_svc.v1.UploadHeader
+	(*UploadStreamMsg)(nil),        // 7: file_svc.v1.UploadStreamMsg
+	(*UploadStreamResp)(nil),       // 8: file_svc.v1.UploadStreamResp
+	(*CreateUploadSessionReq)(nil), // 9: file_svc.v1.CreateUploadSessionReq
+	(*UploadSessionReq)(nil),       // 10: file_svc.v1.UploadSessionReq
+	(*UploadSession)(nil),          // 11: file_svc.v1.UploadSession
+	(*FileReq)(nil),                // 12: file_svc.v1.FileReq
+	(*DownloadReq)(nil),            // 13: file_svc.v1.DownloadReq
+	(*DownloadStreamMsg)(nil),      // 14: file_svc.v1.DownloadStreamMsg
+	(*DeleteFileResp)(nil),         // 15: file_svc.v1.DeleteFileResp
+	(*DeleteFilesReq)(nil),         // 16: file_svc.v1.DeleteFilesReq
+	(*DeleteFileResult)(nil),       // 17: file_svc.v1.DeleteFileResult
+	(*DeleteFilesResp)(nil),        // 18: file_svc.v1.DeleteFilesResp
+	(*FileInfoResp)(nil),           // 19: file_svc.v1.FileInfoResp
+	(*UpdateFileLabelsReq)(nil),    // 20: file_svc.v1.UpdateFileLabelsReq
+	(*UpdateFileInfoReq)(nil),      // 21: file_svc.v1.UpdateFileInfoReq
+	(*CopyFileReq)(nil),            // 22: file_svc.v1.CopyFileReq
+	(*ListFilesReq)(nil),           // 23: file_svc.v1.ListFilesReq
+	(*ListVersionsResp)(nil),       // 24: file_svc.v1.ListVersionsResp
+	(*ListFilesResp)(nil),          // 25: file_svc.v1.ListFilesResp
+	(*Bucket)(nil),                 // 26: file_svc.v1.Bucket
+	(*CreateBucketReq)(nil),        // 27: file_svc.v1.CreateBucketReq
+	(*BucketReq)(nil),              // 28: file_svc.v1.BucketReq
+	(*DeleteBucketResp)(nil),       // 29: file_svc.v1.DeleteBucketResp
+	(*ListBucketsReq)(nil),         // 30: file_svc.v1.ListBucketsReq
+	(*ListBucketsResp)(nil),        // 31: file_svc.v1.ListBucketsResp
+	(*CreateSignedURLReq)(nil),     // 32: file_svc.v1.CreateSignedURLReq
+	(*SignedURL)(nil),              // 33: file_svc.v1.SignedURL
+	nil,                            // 34: file_svc.v1.UploadHeader.LabelsEntry
+	nil,                            // 35: file_svc.v1.UploadHeader.GrantsEntry
+	nil,                            // 36: file_svc.v1.FileInfoResp.LabelsEntry
+	nil,                            // 37: file_svc.v1.FileInfoResp.GrantsEntry
+	nil,                            // 38: file_svc.v1.UpdateFileLabelsReq.LabelsEntry
+	(*timestamppb.Timestamp)(nil),  // 39: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 40: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil),  // 41: google.protobuf.FieldMask
 }
 var file_file_svc_proto_depIdxs = []int32{
-	7, // 0: file_svc.v1.ListFilesResp.files:type_name -> file_svc.v1.FileInfoResp
-	0, // 1: file_svc.v1.FileService.Constraints:input_type -> file_svc.v1.ConstraintsReq
-	2, // 2: file_svc.v1.FileService.UploadStream:input_type -> file_svc.v1.UploadStreamMsg
-	4, // 3: file_svc.v1.FileService.DownloadStream:input_type -> file_svc.v1.FileReq
-	4, // 4: file_svc.v1.FileService.DeleteFile:input_type -> file_svc.v1.FileReq
-	4, // 5: file_svc.v1.FileService.GetFileInfo:input_type -> file_svc.v1.FileReq
-	8, // 6: file_svc.v1.FileService.ListFiles:input_type -> file_svc.v1.ListFilesReq
-	1, // 7: file_svc.v1.FileService.Constraints:output_type -> file_svc.v1.ConstraintsResp
-	3, // 8: file_svc.v1.FileService.UploadStream:output_type -> file_svc.v1.UploadStreamResp
-	5, // 9: file_svc.v1.FileService.DownloadStream:output_type -> file_svc.v1.DownloadStreamMsg
-	6, // 10: file_svc.v1.FileService.DeleteFile:output_type -> file_svc.v1.DeleteFileResp
-	7, // 11: file_svc.v1.FileService.GetFileInfo:output_type -> file_svc.v1.FileInfoResp
-	9, // 12: file_svc.v1.FileService.ListFiles:output_type -> file_svc.v1.ListFilesResp
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	34, // 0: file_svc.v1.UploadHeader.labels:type_name -> file_svc.v1.UploadHeader.LabelsEntry
+	39, // 1: file_svc.v1.UploadHeader.expires_at:type_name -> google.protobuf.Timestamp
+	40, // 2: file_svc.v1.UploadHeader.ttl:type_name -> google.protobuf.Duration
+	35, // 3: file_svc.v1.UploadHeader.grants:type_name -> file_svc.v1.UploadHeader.GrantsEntry
+	6,  // 4: file_svc.v1.UploadStreamMsg.header:type_name -> file_svc.v1.UploadHeader
+	6,  // 5: file_svc.v1.CreateUploadSessionReq.header:type_name -> file_svc.v1.UploadHeader
+	19, // 6: file_svc.v1.DownloadStreamMsg.info:type_name -> file_svc.v1.FileInfoResp
+	0,  // 7: file_svc.v1.DeleteFileResult.result:type_name -> file_svc.v1.DeleteResult
+	17, // 8: file_svc.v1.DeleteFilesResp.results:type_name -> file_svc.v1.DeleteFileResult
+	39, // 9: file_svc.v1.FileInfoResp.created_at:type_name -> google.protobuf.Timestamp
+	36, // 10: file_svc.v1.FileInfoResp.labels:type_name -> file_svc.v1.FileInfoResp.LabelsEntry
+	39, // 11: file_svc.v1.FileInfoResp.deleted_at:type_name -> google.protobuf.Timestamp
+	39, // 12: file_svc.v1.FileInfoResp.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 13: file_svc.v1.FileInfoResp.grants:type_name -> file_svc.v1.FileInfoResp.GrantsEntry
+	38, // 14: file_svc.v1.UpdateFileLabelsReq.labels:type_name -> file_svc.v1.UpdateFileLabelsReq.LabelsEntry
+	19, // 15: file_svc.v1.UpdateFileInfoReq.info:type_name -> file_svc.v1.FileInfoResp
+	41, // 16: file_svc.v1.UpdateFileInfoReq.update_mask:type_name -> google.protobuf.FieldMask
+	39, // 17: file_svc.v1.ListFilesReq.created_after:type_name -> google.protobuf.Timestamp
+	39, // 18: file_svc.v1.ListFilesReq.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 19: file_svc.v1.ListFilesReq.sort_by:type_name -> file_svc.v1.SortField
+	19, // 20: file_svc.v1.ListVersionsResp.versions:type_name -> file_svc.v1.FileInfoResp
+	19, // 21: file_svc.v1.ListFilesResp.files:type_name -> file_svc.v1.FileInfoResp
+	39, // 22: file_svc.v1.Bucket.created_at:type_name -> google.protobuf.Timestamp
+	26, // 23: file_svc.v1.ListBucketsResp.buckets:type_name -> file_svc.v1.Bucket
+	3,  // 24: file_svc.v1.CreateSignedURLReq.method:type_name -> file_svc.v1.SignedMethod
+	40, // 25: file_svc.v1.CreateSignedURLReq.expires_in:type_name -> google.protobuf.Duration
+	39, // 26: file_svc.v1.SignedURL.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 27: file_svc.v1.UploadHeader.GrantsEntry.value:type_name -> file_svc.v1.Permission
+	1,  // 28: file_svc.v1.FileInfoResp.GrantsEntry.value:type_name -> file_svc.v1.Permission
+	4,  // 29: file_svc.v1.FileService.Constraints:input_type -> file_svc.v1.ConstraintsReq
+	7,  // 30: file_svc.v1.FileService.UploadStream:input_type -> file_svc.v1.UploadStreamMsg
+	13, // 31: file_svc.v1.FileService.DownloadStream:input_type -> file_svc.v1.DownloadReq
+	12, // 32: file_svc.v1.FileService.DeleteFile:input_type -> file_svc.v1.FileReq
+	16, // 33: file_svc.v1.FileService.DeleteFiles:input_type -> file_svc.v1.DeleteFilesReq
+	12, // 34: file_svc.v1.FileService.GetFileInfo:input_type -> file_svc.v1.FileReq
+	23, // 35: file_svc.v1.FileService.ListFiles:input_type -> file_svc.v1.ListFilesReq
+	20, // 36: file_svc.v1.FileService.UpdateFileLabels:input_type -> file_svc.v1.UpdateFileLabelsReq
+	21, // 37: file_svc.v1.FileService.UpdateFileInfo:input_type -> file_svc.v1.UpdateFileInfoReq
+	22, // 38: file_svc.v1.FileService.CopyFile:input_type -> file_svc.v1.CopyFileReq
+	27, // 39: file_svc.v1.FileService.CreateBucket:input_type -> file_svc.v1.CreateBucketReq
+	28, // 40: file_svc.v1.FileService.DeleteBucket:input_type -> file_svc.v1.BucketReq
+	30, // 41: file_svc.v1.FileService.ListBuckets:input_type -> file_svc.v1.ListBucketsReq
+	12, // 42: file_svc.v1.FileService.ListVersions:input_type -> file_svc.v1.FileReq
+	12, // 43: file_svc.v1.FileService.RestoreFile:input_type -> file_svc.v1.FileReq
+	23, // 44: file_svc.v1.FileService.ListTrash:input_type -> file_svc.v1.ListFilesReq
+	32, // 45: file_svc.v1.FileService.CreateSignedURL:input_type -> file_svc.v1.CreateSignedURLReq
+	9,  // 46: file_svc.v1.FileService.CreateUploadSession:input_type -> file_svc.v1.CreateUploadSessionReq
+	10, // 47: file_svc.v1.FileService.GetUploadSession:input_type -> file_svc.v1.UploadSessionReq
+	10, // 48: file_svc.v1.FileService.CommitUpload:input_type -> file_svc.v1.UploadSessionReq
+	5,  // 49: file_svc.v1.FileService.Constraints:output_type -> file_svc.v1.ConstraintsResp
+	8,  // 50: file_svc.v1.FileService.UploadStream:output_type -> file_svc.v1.UploadStreamResp
+	14, // 51: file_svc.v1.FileService.DownloadStream:output_type -> file_svc.v1.DownloadStreamMsg
+	15, // 52: file_svc.v1.FileService.DeleteFile:output_type -> file_svc.v1.DeleteFileResp
+	18, // 53: file_svc.v1.FileService.DeleteFiles:output_type -> file_svc.v1.DeleteFilesResp
+	19, // 54: file_svc.v1.FileService.GetFileInfo:output_type -> file_svc.v1.FileInfoResp
+	25, // 55: file_svc.v1.FileService.ListFiles:output_type -> file_svc.v1.ListFilesResp
+	19, // 56: file_svc.v1.FileService.UpdateFileLabels:output_type -> file_svc.v1.FileInfoResp
+	19, // 57: file_svc.v1.FileService.UpdateFileInfo:output_type -> file_svc.v1.FileInfoResp
+	19, // 58: file_svc.v1.FileService.CopyFile:output_type -> file_svc.v1.FileInfoResp
+	26, // 59: file_svc.v1.FileService.CreateBucket:output_type -> file_svc.v1.Bucket
+	29, // 60: file_svc.v1.FileService.DeleteBucket:output_type -> file_svc.v1.DeleteBucketResp
+	31, // 61: file_svc.v1.FileService.ListBuckets:output_type -> file_svc.v1.ListBucketsResp
+	24, // 62: file_svc.v1.FileService.ListVersions:output_type -> file_svc.v1.ListVersionsResp
+	19, // 63: file_svc.v1.FileService.RestoreFile:output_type -> file_svc.v1.FileInfoResp
+	25, // 64: file_svc.v1.FileService.ListTrash:output_type -> file_svc.v1.ListFilesResp
+	33, // 65: file_svc.v1.FileService.CreateSignedURL:output_type -> file_svc.v1.SignedURL
+	11, // 66: file_svc.v1.FileService.CreateUploadSession:output_type -> file_svc.v1.UploadSession
+	11, // 67: file_svc.v1.FileService.GetUploadSession:output_type -> file_svc.v1.UploadSession
+	8,  // 68: file_svc.v1.FileService.CommitUpload:output_type -> file_svc.v1.UploadStreamResp
+	49, // [49:69] is the sub-list for method output_type
+	29, // [29:49] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_file_svc_proto_init() }
@@ -564,18 +2578,23 @@ func file_file_svc_proto_init() {
 	if File_file_svc_proto != nil {
 		return
 	}
+	file_file_svc_proto_msgTypes[3].OneofWrappers = []any{
+		(*UploadStreamMsg_Header)(nil),
+		(*UploadStreamMsg_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_file_svc_proto_rawDesc), len(file_file_svc_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      4,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_file_svc_proto_goTypes,
 		DependencyIndexes: file_file_svc_proto_depIdxs,
+		EnumInfos:         file_file_svc_proto_enumTypes,
 		MessageInfos:      file_file_svc_proto_msgTypes,
 	}.Build()
 	File_file_svc_proto = out.File
@@ -19,12 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FileService_Constraints_FullMethodName    = "/file_svc.v1.FileService/Constraints"
-	FileService_UploadStream_FullMethodName   = "/file_svc.v1.FileService/UploadStream"
-	FileService_DownloadStream_FullMethodName = "/file_svc.v1.FileService/DownloadStream"
-	FileService_DeleteFile_FullMethodName     = "/file_svc.v1.FileService/DeleteFile"
-	FileService_GetFileInfo_FullMethodName    = "/file_svc.v1.FileService/GetFileInfo"
-	FileService_ListFiles_FullMethodName      = "/file_svc.v1.FileService/ListFiles"
+	FileService_Constraints_FullMethodName         = "/file_svc.v1.FileService/Constraints"
+	FileService_UploadStream_FullMethodName        = "/file_svc.v1.FileService/UploadStream"
+	FileService_DownloadStream_FullMethodName      = "/file_svc.v1.FileService/DownloadStream"
+	FileService_DeleteFile_FullMethodName          = "/file_svc.v1.FileService/DeleteFile"
+	FileService_DeleteFiles_FullMethodName         = "/file_svc.v1.FileService/DeleteFiles"
+	FileService_GetFileInfo_FullMethodName         = "/file_svc.v1.FileService/GetFileInfo"
+	FileService_ListFiles_FullMethodName           = "/file_svc.v1.FileService/ListFiles"
+	FileService_UpdateFileLabels_FullMethodName    = "/file_svc.v1.FileService/UpdateFileLabels"
+	FileService_UpdateFileInfo_FullMethodName      = "/file_svc.v1.FileService/UpdateFileInfo"
+	FileService_CopyFile_FullMethodName            = "/file_svc.v1.FileService/CopyFile"
+	FileService_CreateBucket_FullMethodName        = "/file_svc.v1.FileService/CreateBucket"
+	FileService_DeleteBucket_FullMethodName        = "/file_svc.v1.FileService/DeleteBucket"
+	FileService_ListBuckets_FullMethodName         = "/file_svc.v1.FileService/ListBuckets"
+	FileService_ListVersions_FullMethodName        = "/file_svc.v1.FileService/ListVersions"
+	FileService_RestoreFile_FullMethodName         = "/file_svc.v1.FileService/RestoreFile"
+	FileService_ListTrash_FullMethodName           = "/file_svc.v1.FileService/ListTrash"
+	FileService_CreateSignedURL_FullMethodName     = "/file_svc.v1.FileService/CreateSignedURL"
+	FileService_CreateUploadSession_FullMethodName = "/file_svc.v1.FileService/CreateUploadSession"
+	FileService_GetUploadSession_FullMethodName    = "/file_svc.v1.FileService/GetUploadSession"
+	FileService_CommitUpload_FullMethodName        = "/file_svc.v1.FileService/CommitUpload"
 )
 
 // FileServiceClient is the client API for FileService service.
@@ -33,10 +47,24 @@ const (
 type FileServiceClient interface {
 	Constraints(ctx context.Context, in *ConstraintsReq, opts ...grpc.CallOption) (*ConstraintsResp, error)
 	UploadStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadStreamMsg, UploadStreamResp], error)
-	DownloadStream(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamMsg], error)
+	DownloadStream(ctx context.Context, in *DownloadReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamMsg], error)
 	DeleteFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*DeleteFileResp, error)
+	DeleteFiles(ctx context.Context, in *DeleteFilesReq, opts ...grpc.CallOption) (*DeleteFilesResp, error)
 	GetFileInfo(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*FileInfoResp, error)
 	ListFiles(ctx context.Context, in *ListFilesReq, opts ...grpc.CallOption) (*ListFilesResp, error)
+	UpdateFileLabels(ctx context.Context, in *UpdateFileLabelsReq, opts ...grpc.CallOption) (*FileInfoResp, error)
+	UpdateFileInfo(ctx context.Context, in *UpdateFileInfoReq, opts ...grpc.CallOption) (*FileInfoResp, error)
+	CopyFile(ctx context.Context, in *CopyFileReq, opts ...grpc.CallOption) (*FileInfoResp, error)
+	CreateBucket(ctx context.Context, in *CreateBucketReq, opts ...grpc.CallOption) (*Bucket, error)
+	DeleteBucket(ctx context.Context, in *BucketReq, opts ...grpc.CallOption) (*DeleteBucketResp, error)
+	ListBuckets(ctx context.Context, in *ListBucketsReq, opts ...grpc.CallOption) (*ListBucketsResp, error)
+	ListVersions(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*ListVersionsResp, error)
+	RestoreFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*FileInfoResp, error)
+	ListTrash(ctx context.Context, in *ListFilesReq, opts ...grpc.CallOption) (*ListFilesResp, error)
+	CreateSignedURL(ctx context.Context, in *CreateSignedURLReq, opts ...grpc.CallOption) (*SignedURL, error)
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionReq, opts ...grpc.CallOption) (*UploadSession, error)
+	GetUploadSession(ctx context.Context, in *UploadSessionReq, opts ...grpc.CallOption) (*UploadSession, error)
+	CommitUpload(ctx context.Context, in *UploadSessionReq, opts ...grpc.CallOption) (*UploadStreamResp, error)
 }
 
 type fileServiceClient struct {
@@ -70,13 +98,13 @@ func (c *fileServiceClient) UploadStream(ctx context.Context, opts ...grpc.CallO
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type FileService_UploadStreamClient = grpc.ClientStreamingClient[UploadStreamMsg, UploadStreamResp]
 
-func (c *fileServiceClient) DownloadStream(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamMsg], error) {
+func (c *fileServiceClient) DownloadStream(ctx context.Context, in *DownloadReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamMsg], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[1], FileService_DownloadStream_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[FileReq, DownloadStreamMsg]{ClientStream: stream}
+	x := &grpc.GenericClientStream[DownloadReq, DownloadStreamMsg]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -99,6 +127,16 @@ func (c *fileServiceClient) DeleteFile(ctx context.Context, in *FileReq, opts ..
 	return out, nil
 }
 
+func (c *fileServiceClient) DeleteFiles(ctx context.Context, in *DeleteFilesReq, opts ...grpc.CallOption) (*DeleteFilesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFilesResp)
+	err := c.cc.Invoke(ctx, FileService_DeleteFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileServiceClient) GetFileInfo(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*FileInfoResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FileInfoResp)
@@ -119,16 +157,160 @@ func (c *fileServiceClient) ListFiles(ctx context.Context, in *ListFilesReq, opt
 	return out, nil
 }
 
+func (c *fileServiceClient) UpdateFileLabels(ctx context.Context, in *UpdateFileLabelsReq, opts ...grpc.CallOption) (*FileInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResp)
+	err := c.cc.Invoke(ctx, FileService_UpdateFileLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) UpdateFileInfo(ctx context.Context, in *UpdateFileInfoReq, opts ...grpc.CallOption) (*FileInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResp)
+	err := c.cc.Invoke(ctx, FileService_UpdateFileInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CopyFile(ctx context.Context, in *CopyFileReq, opts ...grpc.CallOption) (*FileInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResp)
+	err := c.cc.Invoke(ctx, FileService_CopyFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateBucket(ctx context.Context, in *CreateBucketReq, opts ...grpc.CallOption) (*Bucket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bucket)
+	err := c.cc.Invoke(ctx, FileService_CreateBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeleteBucket(ctx context.Context, in *BucketReq, opts ...grpc.CallOption) (*DeleteBucketResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBucketResp)
+	err := c.cc.Invoke(ctx, FileService_DeleteBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListBuckets(ctx context.Context, in *ListBucketsReq, opts ...grpc.CallOption) (*ListBucketsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBucketsResp)
+	err := c.cc.Invoke(ctx, FileService_ListBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListVersions(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*ListVersionsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResp)
+	err := c.cc.Invoke(ctx, FileService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RestoreFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*FileInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResp)
+	err := c.cc.Invoke(ctx, FileService_RestoreFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListTrash(ctx context.Context, in *ListFilesReq, opts ...grpc.CallOption) (*ListFilesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFilesResp)
+	err := c.cc.Invoke(ctx, FileService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateSignedURL(ctx context.Context, in *CreateSignedURLReq, opts ...grpc.CallOption) (*SignedURL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignedURL)
+	err := c.cc.Invoke(ctx, FileService_CreateSignedURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionReq, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, FileService_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetUploadSession(ctx context.Context, in *UploadSessionReq, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, FileService_GetUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CommitUpload(ctx context.Context, in *UploadSessionReq, opts ...grpc.CallOption) (*UploadStreamResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStreamResp)
+	err := c.cc.Invoke(ctx, FileService_CommitUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileServiceServer is the server API for FileService service.
 // All implementations must embed UnimplementedFileServiceServer
 // for forward compatibility.
 type FileServiceServer interface {
 	Constraints(context.Context, *ConstraintsReq) (*ConstraintsResp, error)
 	UploadStream(grpc.ClientStreamingServer[UploadStreamMsg, UploadStreamResp]) error
-	DownloadStream(*FileReq, grpc.ServerStreamingServer[DownloadStreamMsg]) error
+	DownloadStream(*DownloadReq, grpc.ServerStreamingServer[DownloadStreamMsg]) error
 	DeleteFile(context.Context, *FileReq) (*DeleteFileResp, error)
+	DeleteFiles(context.Context, *DeleteFilesReq) (*DeleteFilesResp, error)
 	GetFileInfo(context.Context, *FileReq) (*FileInfoResp, error)
 	ListFiles(context.Context, *ListFilesReq) (*ListFilesResp, error)
+	UpdateFileLabels(context.Context, *UpdateFileLabelsReq) (*FileInfoResp, error)
+	UpdateFileInfo(context.Context, *UpdateFileInfoReq) (*FileInfoResp, error)
+	CopyFile(context.Context, *CopyFileReq) (*FileInfoResp, error)
+	CreateBucket(context.Context, *CreateBucketReq) (*Bucket, error)
+	DeleteBucket(context.Context, *BucketReq) (*DeleteBucketResp, error)
+	ListBuckets(context.Context, *ListBucketsReq) (*ListBucketsResp, error)
+	ListVersions(context.Context, *FileReq) (*ListVersionsResp, error)
+	RestoreFile(context.Context, *FileReq) (*FileInfoResp, error)
+	ListTrash(context.Context, *ListFilesReq) (*ListFilesResp, error)
+	CreateSignedURL(context.Context, *CreateSignedURLReq) (*SignedURL, error)
+	CreateUploadSession(context.Context, *CreateUploadSessionReq) (*UploadSession, error)
+	GetUploadSession(context.Context, *UploadSessionReq) (*UploadSession, error)
+	CommitUpload(context.Context, *UploadSessionReq) (*UploadStreamResp, error)
 	mustEmbedUnimplementedFileServiceServer()
 }
 
@@ -145,18 +327,60 @@ func (UnimplementedFileServiceServer) Constraints(context.Context, *ConstraintsR
 func (UnimplementedFileServiceServer) UploadStream(grpc.ClientStreamingServer[UploadStreamMsg, UploadStreamResp]) error {
 	return status.Errorf(codes.Unimplemented, "method UploadStream not implemented")
 }
-func (UnimplementedFileServiceServer) DownloadStream(*FileReq, grpc.ServerStreamingServer[DownloadStreamMsg]) error {
+func (UnimplementedFileServiceServer) DownloadStream(*DownloadReq, grpc.ServerStreamingServer[DownloadStreamMsg]) error {
 	return status.Errorf(codes.Unimplemented, "method DownloadStream not implemented")
 }
 func (UnimplementedFileServiceServer) DeleteFile(context.Context, *FileReq) (*DeleteFileResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
 }
+func (UnimplementedFileServiceServer) DeleteFiles(context.Context, *DeleteFilesReq) (*DeleteFilesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFiles not implemented")
+}
 func (UnimplementedFileServiceServer) GetFileInfo(context.Context, *FileReq) (*FileInfoResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileInfo not implemented")
 }
 func (UnimplementedFileServiceServer) ListFiles(context.Context, *ListFilesReq) (*ListFilesResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
 }
+func (UnimplementedFileServiceServer) UpdateFileLabels(context.Context, *UpdateFileLabelsReq) (*FileInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileLabels not implemented")
+}
+func (UnimplementedFileServiceServer) UpdateFileInfo(context.Context, *UpdateFileInfoReq) (*FileInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileInfo not implemented")
+}
+func (UnimplementedFileServiceServer) CopyFile(context.Context, *CopyFileReq) (*FileInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFile not implemented")
+}
+func (UnimplementedFileServiceServer) CreateBucket(context.Context, *CreateBucketReq) (*Bucket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBucket not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteBucket(context.Context, *BucketReq) (*DeleteBucketResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBucket not implemented")
+}
+func (UnimplementedFileServiceServer) ListBuckets(context.Context, *ListBucketsReq) (*ListBucketsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuckets not implemented")
+}
+func (UnimplementedFileServiceServer) ListVersions(context.Context, *FileReq) (*ListVersionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedFileServiceServer) RestoreFile(context.Context, *FileReq) (*FileInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFile not implemented")
+}
+func (UnimplementedFileServiceServer) ListTrash(context.Context, *ListFilesReq) (*ListFilesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedFileServiceServer) CreateSignedURL(context.Context, *CreateSignedURLReq) (*SignedURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSignedURL not implemented")
+}
+func (UnimplementedFileServiceServer) CreateUploadSession(context.Context, *CreateUploadSessionReq) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedFileServiceServer) GetUploadSession(context.Context, *UploadSessionReq) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedFileServiceServer) CommitUpload(context.Context, *UploadSessionReq) (*UploadStreamResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitUpload not implemented")
+}
 func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
 func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
 
@@ -204,11 +428,11 @@ func _FileService_UploadStream_Handler(srv interface{}, stream grpc.ServerStream
 type FileService_UploadStreamServer = grpc.ClientStreamingServer[UploadStreamMsg, UploadStreamResp]
 
 func _FileService_DownloadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(FileReq)
+	m := new(DownloadReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(FileServiceServer).DownloadStream(m, &grpc.GenericServerStream[FileReq, DownloadStreamMsg]{ServerStream: stream})
+	return srv.(FileServiceServer).DownloadStream(m, &grpc.GenericServerStream[DownloadReq, DownloadStreamMsg]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
@@ -232,6 +456,24 @@ func _FileService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_DeleteFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFilesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteFiles(ctx, req.(*DeleteFilesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FileService_GetFileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FileReq)
 	if err := dec(in); err != nil {
@@ -268,6 +510,240 @@ func _FileService_ListFiles_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_UpdateFileLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileLabelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UpdateFileLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UpdateFileLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UpdateFileLabels(ctx, req.(*UpdateFileLabelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_UpdateFileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileInfoReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UpdateFileInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UpdateFileInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UpdateFileInfo(ctx, req.(*UpdateFileInfoReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CopyFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CopyFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CopyFile(ctx, req.(*CopyFileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CreateBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateBucket(ctx, req.(*CreateBucketReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeleteBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteBucket(ctx, req.(*BucketReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListBuckets(ctx, req.(*ListBucketsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListVersions(ctx, req.(*FileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_RestoreFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).RestoreFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_RestoreFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).RestoreFile(ctx, req.(*FileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListTrash(ctx, req.(*ListFilesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CreateSignedURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSignedURLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateSignedURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateSignedURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateSignedURL(ctx, req.(*CreateSignedURLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateUploadSession(ctx, req.(*CreateUploadSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetUploadSession(ctx, req.(*UploadSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CommitUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CommitUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CommitUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CommitUpload(ctx, req.(*UploadSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -283,6 +759,10 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFile",
 			Handler:    _FileService_DeleteFile_Handler,
 		},
+		{
+			MethodName: "DeleteFiles",
+			Handler:    _FileService_DeleteFiles_Handler,
+		},
 		{
 			MethodName: "GetFileInfo",
 			Handler:    _FileService_GetFileInfo_Handler,
@@ -291,6 +771,58 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListFiles",
 			Handler:    _FileService_ListFiles_Handler,
 		},
+		{
+			MethodName: "UpdateFileLabels",
+			Handler:    _FileService_UpdateFileLabels_Handler,
+		},
+		{
+			MethodName: "UpdateFileInfo",
+			Handler:    _FileService_UpdateFileInfo_Handler,
+		},
+		{
+			MethodName: "CopyFile",
+			Handler:    _FileService_CopyFile_Handler,
+		},
+		{
+			MethodName: "CreateBucket",
+			Handler:    _FileService_CreateBucket_Handler,
+		},
+		{
+			MethodName: "DeleteBucket",
+			Handler:    _FileService_DeleteBucket_Handler,
+		},
+		{
+			MethodName: "ListBuckets",
+			Handler:    _FileService_ListBuckets_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _FileService_ListVersions_Handler,
+		},
+		{
+			MethodName: "RestoreFile",
+			Handler:    _FileService_RestoreFile_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _FileService_ListTrash_Handler,
+		},
+		{
+			MethodName: "CreateSignedURL",
+			Handler:    _FileService_CreateSignedURL_Handler,
+		},
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _FileService_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _FileService_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "CommitUpload",
+			Handler:    _FileService_CommitUpload_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
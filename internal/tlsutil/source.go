@@ -0,0 +1,140 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vishenosik/gocherry/pkg/errors"
+)
+
+// reloadCheckInterval limits how often files are checked for changes.
+const reloadCheckInterval = time.Second
+
+// source is PEM data given inline or read from a file.
+type source struct {
+	file string
+	pem  []byte
+}
+
+func newSource(file, pem string) (source, error) {
+	if file != "" && pem != "" {
+		return source{}, errors.New("only one of file and PEM can be set")
+	}
+	return source{file: file, pem: []byte(pem)}, nil
+}
+
+func (src source) empty() bool {
+	return src.file == "" && len(src.pem) == 0
+}
+
+// modTime is zero for inline PEM, which never changes.
+func (src source) modTime() (time.Time, error) {
+	if src.file == "" {
+		return time.Time{}, nil
+	}
+	stat, err := os.Stat(src.file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return stat.ModTime(), nil
+}
+
+func (src source) read() ([]byte, error) {
+	if src.file == "" {
+		return src.pem, nil
+	}
+	return os.ReadFile(src.file)
+}
+
+// reloader keeps the value loaded from sources, loading it again once a file changes.
+// Failed reloads keep the previous value, so a rotation written file by file
+// is picked up once all files are in place.
+type reloader[T any] struct {
+	sources []source
+	load    func(data ...[]byte) (T, error)
+
+	mu      sync.Mutex
+	value   T
+	mods    []time.Time
+	checked time.Time
+}
+
+func newReloader[T any](load func(data ...[]byte) (T, error), sources ...source) (*reloader[T], error) {
+	rl := &reloader[T]{
+		sources: sources,
+		load:    load,
+		mods:    make([]time.Time, len(sources)),
+	}
+	if err := rl.reload(); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+func (rl *reloader[T]) get() T {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if time.Since(rl.checked) >= reloadCheckInterval {
+		rl.checked = time.Now()
+		if rl.changed() {
+			_ = rl.reload()
+		}
+	}
+
+	return rl.value
+}
+
+func (rl *reloader[T]) changed() bool {
+	for i, src := range rl.sources {
+		mod, err := src.modTime()
+		if err != nil || !mod.Equal(rl.mods[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rl *reloader[T]) reload() error {
+	mods := make([]time.Time, len(rl.sources))
+	data := make([][]byte, len(rl.sources))
+
+	for i, src := range rl.sources {
+		mod, err := src.modTime()
+		if err != nil {
+			return err
+		}
+		if data[i], err = src.read(); err != nil {
+			return err
+		}
+		mods[i] = mod
+	}
+
+	value, err := rl.load(data...)
+	if err != nil {
+		return err
+	}
+
+	rl.value = value
+	rl.mods = mods
+	return nil
+}
+
+func loadKeyPair(data ...[]byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(data[0], data[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load key pair")
+	}
+	return &cert, nil
+}
+
+func loadCertPool(data ...[]byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data[0]) {
+		return nil, errors.New("no CA certificates found")
+	}
+	return pool, nil
+}
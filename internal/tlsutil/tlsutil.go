@@ -0,0 +1,159 @@
+// Package tlsutil builds TLS configs from PEM files or strings,
+// reloading files when certificates are rotated.
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/vishenosik/gocherry/pkg/errors"
+	"google.golang.org/grpc/credentials"
+)
+
+// Options selects the TLS material, each item is read from a file or a PEM string.
+type Options struct {
+	CAFile   string
+	CAPEM    string
+	CertFile string
+	CertPEM  string
+	KeyFile  string
+	KeyPEM   string
+	// ServerName overrides the name verified in server certificates.
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+type material struct {
+	ca      *reloader[*x509.CertPool]
+	keyPair *reloader[*tls.Certificate]
+}
+
+func (opts *Options) load() (*material, error) {
+	ca, err := newSource(opts.CAFile, opts.CAPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "CA")
+	}
+	cert, err := newSource(opts.CertFile, opts.CertPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "certificate")
+	}
+	key, err := newSource(opts.KeyFile, opts.KeyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "key")
+	}
+
+	m := &material{}
+
+	if !ca.empty() {
+		if m.ca, err = newReloader(loadCertPool, ca); err != nil {
+			return nil, errors.Wrap(err, "cannot load CA")
+		}
+	}
+
+	if cert.empty() != key.empty() {
+		return nil, errors.New("certificate and key must be set together")
+	}
+
+	if !cert.empty() {
+		if m.keyPair, err = newReloader(loadKeyPair, cert, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ClientCredentials builds the transport credentials of a gRPC client. Servers are
+// verified against the CA, or the system roots without one, and the certificate
+// is presented for mTLS.
+func ClientCredentials(opts Options) (credentials.TransportCredentials, error) {
+	m, err := opts.load()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if m.keyPair != nil {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return m.keyPair.get(), nil
+		}
+	}
+
+	return &clientCredentials{
+		TransportCredentials: credentials.NewTLS(config),
+		config:               config,
+		ca:                   m.ca,
+	}, nil
+}
+
+// clientCredentials verifies servers against the current CA, the standard
+// verification runs with a config built for every handshake.
+type clientCredentials struct {
+	credentials.TransportCredentials
+	config *tls.Config
+	ca     *reloader[*x509.CertPool]
+}
+
+func (cc *clientCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	// the TLS credentials verify the authority rather than the configured name
+	if cc.config.ServerName != "" {
+		authority = cc.config.ServerName
+	}
+
+	if cc.ca == nil {
+		return cc.TransportCredentials.ClientHandshake(ctx, authority, conn)
+	}
+
+	config := cc.config.Clone()
+	config.RootCAs = cc.ca.get()
+
+	return credentials.NewTLS(config).ClientHandshake(ctx, authority, conn)
+}
+
+func (cc *clientCredentials) Clone() credentials.TransportCredentials {
+	config := cc.config.Clone()
+	return &clientCredentials{
+		TransportCredentials: credentials.NewTLS(config),
+		config:               config,
+		ca:                   cc.ca,
+	}
+}
+
+// ServerConfig builds the TLS config of a server presenting the certificate.
+// Client certificates signed by the CA are required when the CA is set.
+func ServerConfig(opts Options) (*tls.Config, error) {
+	m, err := opts.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.keyPair == nil {
+		return nil, errors.New("server certificate and key are required")
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return m.keyPair.get(), nil
+		},
+	}
+
+	if m.ca != nil {
+		config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientConfig := config.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientCAs = m.ca.get()
+			clientConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			return clientConfig, nil
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,351 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+// issue creates a certificate signed by parent, a self signed CA without parent.
+func issue(t *testing.T, parent *testCert, name string, dnsNames []string, ips []net.IP) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	issuer, issuerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+// serve accepts TLS connections with config, handshakes complete on the first read.
+func serve(t *testing.T, config *tls.Config) string {
+	t.Helper()
+
+	// gRPC clients require the HTTP/2 protocol to be negotiated
+	config.NextProtos = []string{"h2"}
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				// connections stay open until the client closes them
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+func handshake(t *testing.T, opts Options, addr string) error {
+	t.Helper()
+
+	creds, err := ClientCredentials(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tlsConn, _, err := creds.ClientHandshake(ctx, addr, conn)
+	if err == nil {
+		tlsConn.Close()
+	}
+	return err
+}
+
+func TestClientCredentials(t *testing.T) {
+	ca := issue(t, nil, "ca", nil, nil)
+	otherCA := issue(t, nil, "other", nil, nil)
+	loopback := []net.IP{net.ParseIP("127.0.0.1")}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(ca.certPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		server  *testCert
+		opts    Options
+		wantErr bool
+	}{
+		{
+			name:   "IP target with IP SAN",
+			server: issue(t, ca, "server", nil, loopback),
+			opts:   Options{CAPEM: ca.certPEM},
+		},
+		{
+			name:   "IP target with IP SAN and CA file",
+			server: issue(t, ca, "server", nil, loopback),
+			opts:   Options{CAFile: caFile},
+		},
+		{
+			name:    "IP target without IP SAN",
+			server:  issue(t, ca, "server", []string{"files.example.com"}, nil),
+			opts:    Options{CAPEM: ca.certPEM},
+			wantErr: true,
+		},
+		{
+			name:    "IP target without IP SAN and CA file",
+			server:  issue(t, ca, "server", []string{"files.example.com"}, nil),
+			opts:    Options{CAFile: caFile},
+			wantErr: true,
+		},
+		{
+			name:   "server name override",
+			server: issue(t, ca, "server", []string{"files.example.com"}, nil),
+			opts:   Options{CAFile: caFile, ServerName: "files.example.com"},
+		},
+		{
+			name:    "wrong server name override",
+			server:  issue(t, ca, "server", []string{"files.example.com"}, nil),
+			opts:    Options{CAFile: caFile, ServerName: "other.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "other CA",
+			server:  issue(t, otherCA, "server", nil, loopback),
+			opts:    Options{CAPEM: ca.certPEM},
+			wantErr: true,
+		},
+		{
+			name:   "insecure skip verify",
+			server: issue(t, otherCA, "server", nil, nil),
+			opts:   Options{CAPEM: ca.certPEM, InsecureSkipVerify: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ServerConfig(Options{CertPEM: tt.server.certPEM, KeyPEM: tt.server.keyPEM})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = handshake(t, tt.opts, serve(t, config))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerConfig_ClientCertificates(t *testing.T) {
+	ca := issue(t, nil, "ca", nil, nil)
+	otherCA := issue(t, nil, "other", nil, nil)
+	server := issue(t, ca, "server", nil, []net.IP{net.ParseIP("127.0.0.1")})
+
+	config, err := ServerConfig(Options{CertPEM: server.certPEM, KeyPEM: server.keyPEM, CAPEM: ca.certPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := serve(t, config)
+
+	client := issue(t, ca, "client", nil, nil)
+	otherClient := issue(t, otherCA, "client", nil, nil)
+
+	tests := []struct {
+		name    string
+		client  *testCert
+		wantErr bool
+	}{
+		{name: "signed by CA", client: client},
+		{name: "signed by other CA", client: otherClient, wantErr: true},
+		{name: "no certificate", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{CAPEM: ca.certPEM}
+			if tt.client != nil {
+				opts.CertPEM, opts.KeyPEM = tt.client.certPEM, tt.client.keyPEM
+			}
+
+			// TLS 1.3 clients learn about a rejected certificate on the first read
+			creds, err := ClientCredentials(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			tlsConn, _, err := creds.ClientHandshake(context.Background(), addr, conn)
+			if err == nil {
+				tlsConn.SetReadDeadline(time.Now().Add(time.Second))
+				_, err = tlsConn.Read(make([]byte, 1))
+				if ne, ok := err.(net.Error); ok && ne.Timeout() {
+					err = nil
+				}
+			}
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_Invalid(t *testing.T) {
+	ca := issue(t, nil, "ca", nil, nil)
+	cert := issue(t, ca, "server", []string{"localhost"}, nil)
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "CA file and PEM", opts: Options{CAFile: "ca.pem", CAPEM: ca.certPEM}},
+		{name: "certificate without key", opts: Options{CertPEM: cert.certPEM}},
+		{name: "key without certificate", opts: Options{KeyPEM: cert.keyPEM}},
+		{name: "invalid CA", opts: Options{CAPEM: "not a certificate"}},
+		{name: "missing CA file", opts: Options{CAFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "mismatched key", opts: Options{CertPEM: cert.certPEM, KeyPEM: ca.keyPEM}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ClientCredentials(tt.opts); err == nil {
+				t.Fatal("got no error")
+			}
+		})
+	}
+
+	if _, err := ServerConfig(Options{CAPEM: ca.certPEM}); err == nil {
+		t.Fatal("server config without certificate got no error")
+	}
+}
+
+func TestReloader_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+
+	oldCA := issue(t, nil, "old", nil, nil)
+	newCA := issue(t, nil, "new", nil, nil)
+
+	if err := os.WriteFile(caFile, []byte(oldCA.certPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := ClientCredentials(Options{CAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := creds.(*clientCredentials).ca
+
+	// a broken file keeps the loaded CA
+	if err := os.WriteFile(caFile, []byte("partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, caFile, time.Now().Add(time.Minute))
+	ca.checked = time.Time{}
+	if !ca.get().Equal(certPool(oldCA)) {
+		t.Fatal("broken CA file replaced the loaded CA")
+	}
+
+	if err := os.WriteFile(caFile, []byte(newCA.certPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, caFile, time.Now().Add(2*time.Minute))
+	ca.checked = time.Time{}
+	if !ca.get().Equal(certPool(newCA)) {
+		t.Fatal("rotated CA file is not loaded")
+	}
+}
+
+func touch(t *testing.T, path string, mod time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func certPool(certs ...*testCert) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range certs {
+		pool.AddCert(cert.cert)
+	}
+	return pool
+}